@@ -0,0 +1,250 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package api holds the spec-independent types every DocWriter renders:
+// Config is the root of a loaded spec, Definition/Operation/Resource are
+// its contents, and ApiGroup/ApiVersion/GroupVersions describe how those
+// contents are organized for the "API Groups" overview page.
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// IncludesDir is where every DocWriter stages the per-item files
+	// Finalize later collects into the built site.
+	IncludesDir = "generators/includes"
+	// BuildDir is where Finalize writes the finished site.
+	BuildDir = "generators/build"
+)
+
+// ApiVersion is a Kubernetes API version, e.g. "v1" or "v1beta1".
+type ApiVersion string
+
+func (v ApiVersion) String() string { return string(v) }
+
+// ApiGroup is a Kubernetes API group, e.g. "apps", or "" for the core
+// group.
+type ApiGroup string
+
+func (g ApiGroup) String() string { return string(g) }
+
+// ApiGroups sorts a list of ApiGroup alphabetically, with the core group
+// ("") always sorting first.
+type ApiGroups []ApiGroup
+
+func (g ApiGroups) Len() int      { return len(g) }
+func (g ApiGroups) Swap(i, j int) { g[i], g[j] = g[j], g[i] }
+func (g ApiGroups) Less(i, j int) bool {
+	if g[i] == g[j] {
+		return false
+	}
+	if g[i] == "" {
+		return true
+	}
+	if g[j] == "" {
+		return false
+	}
+	return g[i] < g[j]
+}
+
+// VersionList sorts a list of ApiVersion.
+type VersionList []ApiVersion
+
+func (v VersionList) Len() int           { return len(v) }
+func (v VersionList) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+func (v VersionList) Less(i, j int) bool { return v[i] < v[j] }
+
+// GroupVersions maps an API group name to the versions it serves.
+type GroupVersions map[string]VersionList
+
+// Discriminator captures an OpenAPI v3 polymorphic type's discriminator,
+// e.g. the "kind" property Kubernetes uses to pick a oneOf branch.
+type Discriminator struct {
+	PropertyName string
+	Mapping      map[string]string
+}
+
+// ContentType is a single entry of an OpenAPI v3 `content` map, e.g. the
+// "application/json" entry of a requestBody.
+type ContentType struct {
+	MediaType string
+	Schema    string
+}
+
+// CELValidation is a single x-kubernetes-validations rule attached to a
+// CRD field's schema.
+type CELValidation struct {
+	Rule    string
+	Message string
+	Reason  string
+}
+
+// Property is a single schema field, keyed by field name on
+// Definition.Properties.
+type Property struct {
+	Type        string
+	Description string
+	// Deprecated is the OpenAPI `deprecated: true` marker, set
+	// independently of whatever the description text says.
+	Deprecated bool
+	// CELValidations lists this field's own x-kubernetes-validations
+	// rules, distinct from Definition.CELValidations which only holds
+	// the schema's top-level rules.
+	CELValidations []CELValidation
+	// PreserveUnknownFields is a CRD field's x-kubernetes-preserve-unknown-fields
+	// marker, set on schemaless fields (e.g. a CRD's spec.x) that accept
+	// arbitrary, unvalidated content.
+	PreserveUnknownFields bool
+	// IntOrString is a CRD field's x-kubernetes-int-or-string marker,
+	// set on fields like IntOrString itself that validate as either an
+	// integer or a string.
+	IntOrString bool
+	// ListType is a CRD field's x-kubernetes-list-type, e.g. "atomic",
+	// "set" or "map", governing how a list's items are merged on a
+	// server-side apply.
+	ListType string
+}
+
+// Definition is a single named schema from the spec, e.g.
+// "io.k8s.api.apps.v1.Deployment".
+type Definition struct {
+	Name        string
+	Version     ApiVersion
+	Group       ApiGroup
+	Description string
+
+	// Properties maps a schema's field names to their Property, used to
+	// render a definition's field table and to diff two spec versions of
+	// the same resource field by field.
+	Properties map[string]Property
+	// Required lists the field names the schema marks required.
+	Required []string
+
+	// OneOf lists the definition names the $ref branches of a polymorphic
+	// (oneOf/anyOf/allOf) type point at, e.g.
+	// ["io.k8s.apimachinery.pkg.util.intstr.int", "io.k8s.apimachinery.pkg.util.intstr.string"].
+	// Inline branches without a $ref are not listed.
+	OneOf []string
+	// Discriminator is set when the spec declares one for this
+	// definition's oneOf/anyOf branches.
+	Discriminator *Discriminator
+	// CELValidations lists the CRD's x-kubernetes-validations rules, if
+	// any, for the "Validations" section of WriteDefinition/WriteResource.
+	CELValidations []CELValidation
+
+	// Storage, Served and Deprecated mirror a CRD version's own flags of
+	// the same name, so WriteDefinition/WriteResource can render them as
+	// badges. They are left false for definitions loaded from a
+	// Swagger 2.0 or OpenAPI v3 document, which have no such concept.
+	Storage    bool
+	Served     bool
+	Deprecated bool
+
+	OperationCategories []OperationCategory
+}
+
+// GroupDisplayName returns the group name used in page titles and TOC
+// entries, with the core group rendered as "core" rather than "".
+func (d *Definition) GroupDisplayName() string {
+	if d.Group == "" {
+		return "core"
+	}
+	return d.Group.String()
+}
+
+var tocIDDisallowed = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// tocKey slugifies "<name> <suffix>" into the anchor used to link to an
+// operation or operation category from its resource's page.
+func tocKey(name, suffix string) string {
+	slug := strings.ToLower(strings.ReplaceAll(fmt.Sprintf("%s %s", name, suffix), " ", "-"))
+	return tocIDDisallowed.ReplaceAllString(slug, "")
+}
+
+// OperationType names an operation's verb, e.g. "Create" or "List".
+type OperationType struct {
+	Name string
+}
+
+// HttpResponse is a single documented HTTP response code for an
+// Operation.
+type HttpResponse struct {
+	Name string
+}
+
+// Operation is a single API operation, e.g.
+// "createAppsV1NamespacedDeployment".
+type Operation struct {
+	ID   string
+	Type OperationType
+
+	Group, Version, Kind, Sub string
+
+	HttpResponses []HttpResponse
+	// Content lists the requestBody media types this operation accepts,
+	// e.g. "application/json" and "application/apply-patch+yaml".
+	Content []ContentType
+}
+
+// GetGroupVersionKindSub returns the group/version/kind/sub-resource this
+// operation acts on.
+func (o *Operation) GetGroupVersionKindSub() (group, version, kind, sub string) {
+	return o.Group, o.Version, o.Kind, o.Sub
+}
+
+// TocID returns the anchor used to link to this operation from d's
+// resource page.
+func (o *Operation) TocID(d *Definition) string {
+	return tocKey(d.Name, o.Type.Name)
+}
+
+// OperationCategory groups related operations (e.g. all "Write"
+// operations) under one heading on a resource page.
+type OperationCategory struct {
+	Name       string
+	Operations []*Operation
+}
+
+// TocID returns the anchor used to link to this category from d's
+// resource page.
+func (oc *OperationCategory) TocID(d *Definition) string {
+	return tocKey(d.Name, oc.Name)
+}
+
+// Resource is a top-level API resource backed by a Definition, e.g.
+// "Pod".
+type Resource struct {
+	Name       string
+	Definition *Definition
+}
+
+// Config holds everything a DocWriter needs to render a full generated
+// site from one loaded spec.
+type Config struct {
+	SpecVersion string
+
+	Definitions []*Definition
+	Resources   []*Resource
+
+	// Operations holds operations the loader couldn't attach to any
+	// Resource's OperationCategories, rendered via
+	// WriteOrphanedOperationsOverview.
+	Operations []*Operation
+}
@@ -0,0 +1,341 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+var asciidocTemplates *template.Template
+
+func init() {
+	base := template.New("base").Funcs(sprig.FuncMap())
+
+	if !globHasMatches("generators/templates/asciidoc/*") {
+		asciidocTemplates = base
+		return
+	}
+
+	var err error
+
+	asciidocTemplates, err = base.ParseGlob("generators/templates/asciidoc/*")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// AsciiDocWriter is the AsciiDoc counterpart of HTMLWriter: same walk over
+// the spec, rendered through generators/templates/asciidoc/ instead, so the
+// generated docs can be included from an Antora or Asciidoctor site.
+type AsciiDocWriter struct {
+	Config *api.Config
+	TOC    TOC
+
+	// currentTOCItem is used to remember the current item between
+	// calls to e.g. WriteResourceCategory() followed by WriteResource().
+	currentTOCItem *TOCItem
+}
+
+func NewAsciiDocWriter(config *api.Config, title string) (DocWriter, error) {
+	writer := AsciiDocWriter{
+		Config: config,
+		TOC: TOC{
+			Title:    title,
+			Sections: []*TOCItem{},
+		},
+	}
+
+	return &writer, nil
+}
+
+func (a *AsciiDocWriter) render(filename string, data any) (string, error) {
+	var buf strings.Builder
+
+	if err := renderTo(asciidocTemplates, &buf, filename, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func (a *AsciiDocWriter) writeStaticFile(filename, templateName string, data any) error {
+	content, err := a.render(templateName, data)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(api.IncludesDir, filename), []byte(content), 0644)
+}
+
+func (a *AsciiDocWriter) WriteOverview() error {
+	filename := "_overview.adoc"
+	if err := a.writeStaticFile(filename, "section-heading.adoc", "API Overview"); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level:      1,
+		PlainTitle: "Overview",
+		Link:       "api-overview",
+		File:       filename,
+	}
+	a.TOC.Sections = append(a.TOC.Sections, &item)
+	a.currentTOCItem = &item
+
+	return nil
+}
+
+func (a *AsciiDocWriter) WriteAPIGroupVersions(gvs api.GroupVersions) error {
+	groups := api.ApiGroups{}
+	for group := range gvs {
+		groups = append(groups, api.ApiGroup(group))
+	}
+	sort.Sort(groups)
+
+	tplGroups := []map[string]any{}
+
+	for _, group := range groups {
+		versionList := gvs[group.String()]
+		sort.Sort(versionList)
+		var versions []string
+		for _, v := range versionList {
+			versions = append(versions, v.String())
+		}
+
+		tplGroups = append(tplGroups, map[string]any{
+			"group":    group,
+			"versions": versions,
+		})
+	}
+
+	fn := "_api_groups.adoc"
+	content, err := a.render("api-groups.adoc", map[string]any{
+		"groups": tplGroups,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(api.IncludesDir, fn), []byte(content), 0644); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level:      1,
+		PlainTitle: "API Groups",
+		Link:       "api-groups",
+		File:       fn,
+	}
+	a.TOC.Sections = append(a.TOC.Sections, &item)
+	a.currentTOCItem = &item
+
+	return nil
+}
+
+func (a *AsciiDocWriter) WriteResourceCategory(name, file string) error {
+	if err := a.writeStaticFile("_"+file+".adoc", "resource-category-heading.adoc", name); err != nil {
+		return err
+	}
+
+	link := strings.ReplaceAll(strings.ToLower(name), " ", "-")
+	item := TOCItem{
+		Level:      1,
+		PlainTitle: name,
+		Link:       link,
+		File:       "_" + file + ".adoc",
+	}
+	a.TOC.Sections = append(a.TOC.Sections, &item)
+	a.currentTOCItem = &item
+
+	return nil
+}
+
+func (a *AsciiDocWriter) WriteDefinitionsOverview() error {
+	if err := a.writeStaticFile("_definitions.adoc", "section-heading.adoc", "Definitions"); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level:      1,
+		PlainTitle: "Definitions",
+		Link:       "definitions",
+		File:       "_definitions.adoc",
+	}
+	a.TOC.Sections = append(a.TOC.Sections, &item)
+	a.currentTOCItem = &item
+
+	return nil
+}
+
+func (a *AsciiDocWriter) WriteOrphanedOperationsOverview() error {
+	if err := a.writeStaticFile("_operations.adoc", "section-heading.adoc", "Operations"); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level:      1,
+		PlainTitle: "Operations",
+		Link:       "operations",
+		File:       "_operations.adoc",
+	}
+	a.TOC.Sections = append(a.TOC.Sections, &item)
+	a.currentTOCItem = &item
+
+	return nil
+}
+
+func (a *AsciiDocWriter) WriteDefinition(d *api.Definition) error {
+	fn := definitionFileName(d, ".adoc")
+	nvg := fmt.Sprintf("%s %s %s", d.Name, d.Version, d.GroupDisplayName())
+	linkID := getLink(nvg)
+
+	content, err := a.render("definition.adoc", map[string]any{
+		"nvg":        nvg,
+		"linkID":     linkID,
+		"definition": d,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(api.IncludesDir, fn), []byte(content), 0644); err != nil {
+		return err
+	}
+
+	// Definitions are added to the TOC to enable the generator to later collect
+	// all the individual definition files, but definitions will not show up
+	// in the nav tree because it would take up too much screen estate.
+	item := TOCItem{
+		Level:      2,
+		PlainTitle: nvg,
+		Link:       linkID,
+		File:       fn,
+	}
+	a.currentTOCItem.SubSections = append(a.currentTOCItem.SubSections, &item)
+
+	return nil
+}
+
+func (a *AsciiDocWriter) WriteOperation(o *api.Operation) error {
+	fn := operationFileName(o, ".adoc")
+	nvg := o.ID
+	linkID := getLink(nvg)
+
+	content, err := a.render("operation.adoc", map[string]any{
+		"linkID":    linkID,
+		"nvg":       nvg,
+		"operation": o,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(api.IncludesDir, fn), []byte(content), 0644); err != nil {
+		return err
+	}
+
+	sort.Slice(o.HttpResponses, func(i, j int) bool {
+		return strings.Compare(o.HttpResponses[i].Name, o.HttpResponses[j].Name) < 0
+	})
+
+	item := TOCItem{
+		Level:      2,
+		PlainTitle: nvg,
+		Link:       linkID,
+		File:       fn,
+	}
+	a.currentTOCItem.SubSections = append(a.currentTOCItem.SubSections, &item)
+
+	return nil
+}
+
+func (a *AsciiDocWriter) WriteResource(r *api.Resource) error {
+	filename := conceptFileName(r.Definition, ".adoc")
+	dvg := fmt.Sprintf("%s %s %s", r.Name, r.Definition.Version, r.Definition.GroupDisplayName())
+	linkID := getLink(dvg)
+
+	resourceItem := TOCItem{
+		Level:      2,
+		PlainTitle: dvg,
+		Link:       linkID,
+		File:       filename,
+	}
+	a.currentTOCItem.SubSections = append(a.currentTOCItem.SubSections, &resourceItem)
+
+	resourceItem.SubSections = append(resourceItem.SubSections, operationCategoryTOCItems(r.Definition, nil)...)
+
+	content, err := a.render("resource.adoc", map[string]any{
+		"resource": r,
+		"dvg":      dvg,
+		"linkID":   linkID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(api.IncludesDir, filename), []byte(content), 0644)
+}
+
+func (a *AsciiDocWriter) WriteOldVersionsOverview() error {
+	if err := a.writeStaticFile("_oldversions.adoc", "section-heading.adoc", "Old API Versions"); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level:      1,
+		PlainTitle: "Old API Versions",
+		Link:       "old-api-versions",
+		File:       "_oldversions.adoc",
+	}
+	a.TOC.Sections = append(a.TOC.Sections, &item)
+	a.currentTOCItem = &item
+
+	return nil
+}
+
+func (a *AsciiDocWriter) Finalize() error {
+	if err := os.MkdirAll(api.BuildDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	return a.generateIndex()
+}
+
+func (a *AsciiDocWriter) generateIndex() error {
+	// collect content from all the individual files we just created
+	content := collectTOCContent(a.TOC.Sections, false)
+
+	out, err := a.render("index.adoc", map[string]any{
+		"toc":     a.TOC,
+		"config":  a.Config,
+		"content": content,
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(api.BuildDir, "index.adoc"), []byte(out), 0644)
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"strings"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+// FormatCELValidations renders a definition's CEL rules (api.Definition's
+// CELValidations, populated from a CRD's x-kubernetes-validations by
+// LoadConfig) as the display lines the "Validations" section lists them
+// with, falling back to the rule expression itself when no custom message
+// was set.
+func FormatCELValidations(rules []api.CELValidation) []string {
+	lines := make([]string, 0, len(rules))
+
+	for _, r := range rules {
+		msg := strings.TrimSpace(r.Message)
+		if msg == "" {
+			msg = strings.TrimSpace(r.Rule)
+		}
+
+		lines = append(lines, msg)
+	}
+
+	return lines
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+func TestFormatCELValidations(t *testing.T) {
+	rules := []api.CELValidation{
+		{Rule: "self >= 0", Message: "replicas must not be negative"},
+		{Rule: "self.size() > 0"},
+	}
+
+	want := []string{"replicas must not be negative", "self.size() > 0"}
+
+	if got := FormatCELValidations(rules); !reflect.DeepEqual(got, want) {
+		t.Errorf("FormatCELValidations(%+v) = %v, want %v", rules, got, want)
+	}
+}
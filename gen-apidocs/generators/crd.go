@@ -0,0 +1,288 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+
+	"gopkg.in/yaml.v2"
+)
+
+// CRDSource lists the raw CustomResourceDefinition manifests
+// LoadCRDResources synthesizes api.Resource entries from, so docs can be
+// generated for CRDs installed alongside Kubernetes' own built-in API
+// instead of only from one merged upstream spec document.
+type CRDSource interface {
+	// List returns the raw bytes of every CustomResourceDefinition
+	// manifest the source makes available, as either YAML or JSON.
+	List() ([][]byte, error)
+}
+
+// MapCRDSource is a CRDSource backed by manifests already loaded into
+// memory, the CRD counterpart of MapSource.
+type MapCRDSource struct {
+	Docs [][]byte
+}
+
+func (s MapCRDSource) List() ([][]byte, error) {
+	return s.Docs, nil
+}
+
+// CRDDirSource reads every *.yaml/*.yml file in a directory as one
+// CustomResourceDefinition manifest.
+type CRDDirSource struct {
+	Dir string
+}
+
+func (s CRDDirSource) List() ([][]byte, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading CRD directory %q: %w", s.Dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml":
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	docs := make([][]byte, 0, len(names))
+	for _, name := range names {
+		raw, err := os.ReadFile(filepath.Join(s.Dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("reading CRD manifest %q: %w", name, err)
+		}
+		docs = append(docs, raw)
+	}
+
+	return docs, nil
+}
+
+// CRDKubeconfigSource lists every CustomResourceDefinition installed in
+// the cluster named by a kubeconfig file's current context, the
+// live-cluster counterpart to CRDDirSource. It shares KubeconfigSource's
+// bearer-token HTTPSource plumbing rather than re-implementing auth.
+type CRDKubeconfigSource struct {
+	Path string
+}
+
+func (s CRDKubeconfigSource) List() ([][]byte, error) {
+	source, err := (KubeconfigSource{Path: s.Path}).httpSource()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := source.get("/apis/apiextensions.k8s.io/v1/customresourcedefinitions")
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("decoding CustomResourceDefinitionList: %w", err)
+	}
+
+	docs := make([][]byte, len(list.Items))
+	for i, item := range list.Items {
+		docs[i] = []byte(item)
+	}
+
+	return docs, nil
+}
+
+// LoadCRDResources synthesizes one api.Resource per
+// CustomResourceDefinition version, served or not, from source's manifests, the CRD
+// counterpart to LoadConfig. Each version's
+// spec.versions[].schema.openAPIV3Schema is translated exactly like an
+// OpenAPI v3 components.schemas entry, since CRD validation schemas use
+// the same JSON Schema dialect, then stamped with the version's own
+// group/version/storage/served/deprecated flags, which
+// definitionFromV3Schema has no way to know about on its own.
+//
+// Unlike the $ref-linked schemas LoadConfig reads, a CRD's schema inlines
+// its nested objects (e.g. spec.template.spec) directly rather than
+// pointing at another named components.schemas entry, so each such
+// nested object is flattened into its own api.Definition here — named
+// "<Kind>.<version>.<field path>" — and returned alongside the resources,
+// the same way Config.Definitions holds every named schema a Resource's
+// Definition can point into.
+func LoadCRDResources(source CRDSource) ([]*api.Resource, []*api.Definition, error) {
+	docs, err := source.List()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var resources []*api.Resource
+	var definitions []*api.Definition
+
+	for _, raw := range docs {
+		manifest, err := decodeYAMLOrJSON(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fromManifest, nested, err := resourcesFromCRDManifest(manifest)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		resources = append(resources, fromManifest...)
+		definitions = append(definitions, nested...)
+	}
+
+	return resources, definitions, nil
+}
+
+// resourcesFromCRDManifest builds one api.Resource per version a single
+// CustomResourceDefinition manifest declares, plus the flattened
+// api.Definitions for every version's nested object fields.
+func resourcesFromCRDManifest(manifest map[string]any) ([]*api.Resource, []*api.Definition, error) {
+	spec, _ := manifest["spec"].(map[string]any)
+
+	group, _ := spec["group"].(string)
+
+	names, _ := spec["names"].(map[string]any)
+	kind, _ := names["kind"].(string)
+	if kind == "" {
+		return nil, nil, fmt.Errorf("CRD manifest has no spec.names.kind")
+	}
+
+	versions, _ := spec["versions"].([]any)
+
+	resources := make([]*api.Resource, 0, len(versions))
+	var definitions []*api.Definition
+
+	for _, raw := range versions {
+		version, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		name, _ := version["name"].(string)
+		schema, _ := version["schema"].(map[string]any)
+		openAPIV3Schema, _ := schema["openAPIV3Schema"].(map[string]any)
+
+		baseName := fmt.Sprintf("%s.%s", kind, name)
+		d, nested := definitionsFromCRDSchema(baseName, openAPIV3Schema)
+		d.Name = kind
+		d.Group = api.ApiGroup(group)
+		d.Version = api.ApiVersion(name)
+		d.Served, _ = version["served"].(bool)
+		d.Storage, _ = version["storage"].(bool)
+		d.Deprecated, _ = version["deprecated"].(bool)
+
+		resources = append(resources, &api.Resource{Name: kind, Definition: d})
+		definitions = append(definitions, nested...)
+	}
+
+	return resources, definitions, nil
+}
+
+// definitionsFromCRDSchema translates schema via definitionFromV3Schema,
+// then recursively flattens every nested object-typed property (a
+// property whose own schema declares further "properties", as opposed to
+// a schemaless object left alone by x-kubernetes-preserve-unknown-fields)
+// into its own named api.Definition, rewriting the parent property's Type
+// to that name so a reader can follow it the way a $ref-backed Property
+// would be followed in a non-CRD spec.
+func definitionsFromCRDSchema(name string, schema map[string]any) (*api.Definition, []*api.Definition) {
+	d := definitionFromV3Schema(name, schema)
+
+	var nested []*api.Definition
+
+	rawProps, _ := schema["properties"].(map[string]any)
+	for propName, propSchema := range rawProps {
+		prop, ok := propSchema.(map[string]any)
+		if !ok {
+			continue
+		}
+		if t, _ := prop["type"].(string); t != "object" {
+			continue
+		}
+		if _, hasNestedProps := prop["properties"]; !hasNestedProps {
+			continue
+		}
+
+		childName := name + "." + propName
+		child, grandchildren := definitionsFromCRDSchema(childName, prop)
+
+		if existing, ok := d.Properties[propName]; ok {
+			existing.Type = childName
+			d.Properties[propName] = existing
+		}
+
+		nested = append(nested, child)
+		nested = append(nested, grandchildren...)
+	}
+
+	return d, nested
+}
+
+// decodeYAMLOrJSON parses a CRD manifest — YAML from a file, or JSON from
+// a live apiserver's CustomResourceDefinitionList — into the
+// map[string]any shape definitionFromV3Schema and its helpers already
+// walk.
+func decodeYAMLOrJSON(raw []byte) (map[string]any, error) {
+	var parsed any
+	if err := yaml.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("parsing CRD manifest: %w", err)
+	}
+
+	manifest, ok := normalizeYAML(parsed).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("CRD manifest is not a YAML/JSON object")
+	}
+
+	return manifest, nil
+}
+
+// normalizeYAML converts the map[interface{}]interface{} gopkg.in/yaml.v2
+// decodes nested maps into, to the map[string]any shape the rest of this
+// package's schema-walking code (shared with the OpenAPI v3 loader)
+// already expects.
+func normalizeYAML(v any) any {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]any, len(v))
+		for key, val := range v {
+			m[fmt.Sprintf("%v", key)] = normalizeYAML(val)
+		}
+		return m
+	case []interface{}:
+		list := make([]any, len(v))
+		for i, item := range v {
+			list[i] = normalizeYAML(item)
+		}
+		return list
+	default:
+		return v
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+const testCRDManifest = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: crontabs.stable.example.com
+spec:
+  group: stable.example.com
+  names:
+    kind: CronTab
+    plural: crontabs
+    listKind: CronTabList
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              cronSpec:
+                type: string
+              replicas:
+                type: integer
+                x-kubernetes-int-or-string: true
+              ports:
+                type: array
+                x-kubernetes-list-type: set
+              extra:
+                type: object
+                x-kubernetes-preserve-unknown-fields: true
+                x-kubernetes-validations:
+                - rule: "self.size() < 10"
+                  message: "extra must be small"
+  - name: v1beta1
+    served: false
+    storage: false
+    deprecated: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+`
+
+func TestCRDDirSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "crontabs.yaml"), []byte(testCRDManifest), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a CRD"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	docs, err := (CRDDirSource{Dir: dir}).List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("List() returned %d docs, want 1", len(docs))
+	}
+}
+
+func TestCRDKubeconfigSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/apiextensions.k8s.io/v1/customresourcedefinitions" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"items": [{"spec": {"group": "stable.example.com", "names": {"kind": "CronTab"}, "versions": []}}]}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "kubeconfig.yaml")
+	contents := fmt.Sprintf(`
+current-context: test
+contexts:
+- name: test
+  context:
+    cluster: test-cluster
+    user: test-user
+clusters:
+- name: test-cluster
+  cluster:
+    server: %s
+users:
+- name: test-user
+  user:
+    token: s3cr3t
+`, server.URL)
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	docs, err := (CRDKubeconfigSource{Path: path}).List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("List() returned %d docs, want 1", len(docs))
+	}
+}
+
+func TestLoadCRDResources(t *testing.T) {
+	resources, definitions, err := LoadCRDResources(MapCRDSource{Docs: [][]byte{[]byte(testCRDManifest)}})
+	if err != nil {
+		t.Fatalf("LoadCRDResources() error = %v", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("LoadCRDResources() returned %d resources, want 2", len(resources))
+	}
+
+	v1 := resources[0]
+	if v1.Name != "CronTab" {
+		t.Errorf("resources[0].Name = %q, want %q", v1.Name, "CronTab")
+	}
+	d := v1.Definition
+	if d.Group != api.ApiGroup("stable.example.com") || d.Version != api.ApiVersion("v1") {
+		t.Errorf("resources[0].Definition group/version = %q/%q, want %q/%q", d.Group, d.Version, "stable.example.com", "v1")
+	}
+	if !d.Served || !d.Storage || d.Deprecated {
+		t.Errorf("resources[0].Definition served/storage/deprecated = %v/%v/%v, want true/true/false", d.Served, d.Storage, d.Deprecated)
+	}
+
+	specProp, ok := d.Properties["spec"]
+	if !ok {
+		t.Fatalf("resources[0].Definition.Properties has no \"spec\" entry")
+	}
+	if specProp.Type != "CronTab.v1.spec" {
+		t.Errorf("resources[0].Definition.Properties[\"spec\"].Type = %q, want %q", specProp.Type, "CronTab.v1.spec")
+	}
+
+	var nested *api.Definition
+	for _, def := range definitions {
+		if def.Name == "CronTab.v1.spec" {
+			nested = def
+		}
+	}
+	if nested == nil {
+		t.Fatalf("LoadCRDResources() did not flatten a %q definition, got %v", "CronTab.v1.spec", definitionNames(definitions))
+	}
+
+	replicas := nested.Properties["replicas"]
+	if !replicas.IntOrString {
+		t.Errorf("spec.replicas.IntOrString = false, want true")
+	}
+	ports := nested.Properties["ports"]
+	if ports.ListType != "set" {
+		t.Errorf("spec.ports.ListType = %q, want %q", ports.ListType, "set")
+	}
+	extra := nested.Properties["extra"]
+	if !extra.PreserveUnknownFields {
+		t.Errorf("spec.extra.PreserveUnknownFields = false, want true")
+	}
+	if len(extra.CELValidations) != 1 || extra.CELValidations[0].Message != "extra must be small" {
+		t.Errorf("spec.extra.CELValidations = %+v, want one rule with message %q", extra.CELValidations, "extra must be small")
+	}
+	if extra.Type != "object" {
+		t.Errorf("spec.extra.Type = %q, want %q (schemaless objects aren't flattened into their own Definition)", extra.Type, "object")
+	}
+
+	v1beta1 := resources[1]
+	if v1beta1.Definition.Version != api.ApiVersion("v1beta1") || !v1beta1.Definition.Deprecated {
+		t.Errorf("resources[1].Definition version/deprecated = %q/%v, want %q/true", v1beta1.Definition.Version, v1beta1.Definition.Deprecated, "v1beta1")
+	}
+}
+
+func definitionNames(definitions []*api.Definition) []string {
+	names := make([]string, len(definitions))
+	for i, d := range definitions {
+		names[i] = d.Name
+	}
+	return names
+}
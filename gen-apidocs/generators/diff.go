@@ -0,0 +1,234 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+// DiffReport summarizes the differences between two spec versions, keyed
+// the same way WriteResource/WriteDefinition already key their TOC
+// entries and link IDs, so WriteDiffOverview can render a changelog-style
+// supplement instead of requiring readers to hand-compare two generated
+// sites.
+type DiffReport struct {
+	OldSpecVersion string
+	NewSpecVersion string
+
+	NewResources     []string
+	RemovedResources []string
+	ChangedResources []ResourceDiff
+
+	// NewlyDeprecatedFields maps a resource key to the fields whose
+	// description started mentioning "Deprecated" (or gained
+	// `deprecated: true`) only in the new spec.
+	NewlyDeprecatedFields map[string][]string
+}
+
+// ResourceDiff is keyed by the "<name> <version> <group>" identity
+// WriteResource already builds a link ID from.
+type ResourceDiff struct {
+	Key string
+
+	AddedFields     []string
+	RemovedFields   []string
+	ChangedTypes    map[string]TypeChange
+	AddedRequired   []string
+	RemovedRequired []string
+}
+
+// TypeChange records a field whose type changed between spec versions.
+type TypeChange struct {
+	OldType string
+	NewType string
+}
+
+// resourceKey mirrors the "<name> <version> <group>" identity
+// HTMLWriter.WriteResource already builds a link ID from.
+func resourceKey(r *api.Resource) string {
+	return fmt.Sprintf("%s %s %s", r.Name, r.Definition.Version, r.Definition.GroupDisplayName())
+}
+
+// BuildDiffReport compares the resources loaded from two spec versions and
+// returns the report WriteDiffOverview renders.
+func BuildDiffReport(oldSpecVersion, newSpecVersion string, oldResources, newResources []*api.Resource) *DiffReport {
+	report := &DiffReport{
+		OldSpecVersion:        oldSpecVersion,
+		NewSpecVersion:        newSpecVersion,
+		NewlyDeprecatedFields: map[string][]string{},
+	}
+
+	oldByKey := map[string]*api.Resource{}
+	for _, r := range oldResources {
+		oldByKey[resourceKey(r)] = r
+	}
+
+	newByKey := map[string]*api.Resource{}
+	for _, r := range newResources {
+		newByKey[resourceKey(r)] = r
+	}
+
+	for key := range newByKey {
+		old, ok := oldByKey[key]
+		if !ok {
+			report.NewResources = append(report.NewResources, key)
+			continue
+		}
+
+		diff, deprecated := diffResource(key, old.Definition, newByKey[key].Definition)
+		if diff != nil {
+			report.ChangedResources = append(report.ChangedResources, *diff)
+		}
+		if len(deprecated) > 0 {
+			report.NewlyDeprecatedFields[key] = deprecated
+		}
+	}
+
+	for key := range oldByKey {
+		if _, ok := newByKey[key]; !ok {
+			report.RemovedResources = append(report.RemovedResources, key)
+		}
+	}
+
+	sort.Strings(report.NewResources)
+	sort.Strings(report.RemovedResources)
+	sort.Slice(report.ChangedResources, func(i, j int) bool {
+		return report.ChangedResources[i].Key < report.ChangedResources[j].Key
+	})
+
+	return report
+}
+
+// diffResource compares two versions of the same resource's Definition
+// field by field, returning the ResourceDiff to render (nil if nothing
+// but deprecation notices changed) and the fields newly marked deprecated
+// in newDef.
+func diffResource(key string, oldDef, newDef *api.Definition) (*ResourceDiff, []string) {
+	diff := ResourceDiff{Key: key, ChangedTypes: map[string]TypeChange{}}
+
+	for name, newProp := range newDef.Properties {
+		oldProp, existed := oldDef.Properties[name]
+		if !existed {
+			diff.AddedFields = append(diff.AddedFields, name)
+			continue
+		}
+
+		if oldProp.Type != newProp.Type {
+			diff.ChangedTypes[name] = TypeChange{OldType: oldProp.Type, NewType: newProp.Type}
+		}
+	}
+
+	for name := range oldDef.Properties {
+		if _, ok := newDef.Properties[name]; !ok {
+			diff.RemovedFields = append(diff.RemovedFields, name)
+		}
+	}
+
+	oldRequired := map[string]bool{}
+	for _, name := range oldDef.Required {
+		oldRequired[name] = true
+	}
+	newRequired := map[string]bool{}
+	for _, name := range newDef.Required {
+		newRequired[name] = true
+	}
+
+	for name := range newRequired {
+		if !oldRequired[name] {
+			diff.AddedRequired = append(diff.AddedRequired, name)
+		}
+	}
+	for name := range oldRequired {
+		if !newRequired[name] {
+			diff.RemovedRequired = append(diff.RemovedRequired, name)
+		}
+	}
+
+	sort.Strings(diff.AddedFields)
+	sort.Strings(diff.RemovedFields)
+	sort.Strings(diff.AddedRequired)
+	sort.Strings(diff.RemovedRequired)
+
+	var deprecated []string
+	for name, newProp := range newDef.Properties {
+		oldProp := oldDef.Properties[name]
+		if isDeprecated(newProp) && !isDeprecated(oldProp) {
+			deprecated = append(deprecated, name)
+		}
+	}
+	sort.Strings(deprecated)
+
+	if len(diff.AddedFields) == 0 && len(diff.RemovedFields) == 0 &&
+		len(diff.ChangedTypes) == 0 && len(diff.AddedRequired) == 0 && len(diff.RemovedRequired) == 0 {
+		return nil, deprecated
+	}
+
+	return &diff, deprecated
+}
+
+// isDeprecated reports whether a field is marked deprecated, either by its
+// OpenAPI v3 `deprecated: true` marker or by its description mentioning
+// "Deprecated" the way Kubernetes' own API docs do.
+func isDeprecated(prop api.Property) bool {
+	return prop.Deprecated || isDeprecatedDescription(prop.Description)
+}
+
+// isDeprecatedDescription reports whether a field's description mentions
+// "Deprecated", the textual convention Kubernetes' own API docs use ahead
+// of (or instead of) the `deprecated: true` marker.
+func isDeprecatedDescription(description string) bool {
+	return strings.Contains(description, "Deprecated")
+}
+
+// GenerateDiffOverview builds the diff between two loaded spec versions
+// and writes it through h as a "Changes" section. Called right before
+// WriteOldVersionsOverview, it places that section ahead of the old API
+// versions.
+func GenerateDiffOverview(h *HTMLWriter, oldSpecVersion, newSpecVersion string, oldResources, newResources []*api.Resource) error {
+	report := BuildDiffReport(oldSpecVersion, newSpecVersion, oldResources, newResources)
+	return h.WriteDiffOverview(report)
+}
+
+// WriteDiffOverview renders the changelog-style supplement produced by
+// BuildDiffReport.
+func (h *HTMLWriter) WriteDiffOverview(report *DiffReport) error {
+	content, err := renderTemplate("diff.html", report)
+	if err != nil {
+		return err
+	}
+
+	filename := "_diff.html"
+	if err := writeStaticFile(filename, content); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level:      1,
+		Title:      "CHANGES",
+		PlainTitle: "Changes",
+		Link:       "changes",
+		File:       filename,
+	}
+	h.TOC.Sections = append(h.TOC.Sections, &item)
+	h.currentTOCItem = &item
+
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+func TestIsDeprecatedDescription(t *testing.T) {
+	cases := []struct {
+		description string
+		want        bool
+	}{
+		{"Deprecated. Use spec.replicas instead.", true},
+		{"  Deprecated: use foo", true},
+		{"DEPRECATED - this field is not used.", false},
+		{"podFQDN is Deprecated, use hostname instead.", true},
+		{"Number of desired pods.", false},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		if got := isDeprecatedDescription(c.description); got != c.want {
+			t.Errorf("isDeprecatedDescription(%q) = %v, want %v", c.description, got, c.want)
+		}
+	}
+}
+
+func TestIsDeprecated(t *testing.T) {
+	cases := []struct {
+		name string
+		prop api.Property
+		want bool
+	}{
+		{"neither", api.Property{Description: "Number of desired pods."}, false},
+		{"description only", api.Property{Description: "Deprecated. Use spec.replicas instead."}, true},
+		{"marker only", api.Property{Deprecated: true, Description: "Number of desired pods."}, true},
+		{"both", api.Property{Deprecated: true, Description: "Deprecated. Use spec.replicas instead."}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isDeprecated(c.prop); got != c.want {
+				t.Errorf("isDeprecated(%+v) = %v, want %v", c.prop, got, c.want)
+			}
+		})
+	}
+}
+
+func TestBuildDiffReport(t *testing.T) {
+	podDef := func(properties map[string]api.Property, required []string) *api.Definition {
+		return &api.Definition{
+			Name:       "Pod",
+			Version:    "v1",
+			Properties: properties,
+			Required:   required,
+		}
+	}
+
+	oldResources := []*api.Resource{
+		{Name: "Pod", Definition: podDef(map[string]api.Property{
+			"spec":   {Type: "object"},
+			"status": {Type: "object"},
+		}, []string{"spec"})},
+		{Name: "ReplicationController", Definition: &api.Definition{Name: "ReplicationController", Version: "v1"}},
+	}
+
+	newResources := []*api.Resource{
+		{Name: "Pod", Definition: podDef(map[string]api.Property{
+			"spec":     {Type: "string"},
+			"metadata": {Type: "object", Description: "Deprecated. Standard object metadata."},
+		}, []string{"spec", "metadata"})},
+		{Name: "Deployment", Definition: &api.Definition{Name: "Deployment", Version: "v1"}},
+	}
+
+	report := BuildDiffReport("v1.29.0", "v1.30.0", oldResources, newResources)
+
+	if want := []string{"Deployment v1 core"}; !reflect.DeepEqual(report.NewResources, want) {
+		t.Errorf("NewResources = %v, want %v", report.NewResources, want)
+	}
+	if want := []string{"ReplicationController v1 core"}; !reflect.DeepEqual(report.RemovedResources, want) {
+		t.Errorf("RemovedResources = %v, want %v", report.RemovedResources, want)
+	}
+
+	if len(report.ChangedResources) != 1 {
+		t.Fatalf("len(ChangedResources) = %d, want 1", len(report.ChangedResources))
+	}
+
+	podDiff := report.ChangedResources[0]
+	if want := []string{"metadata"}; !reflect.DeepEqual(podDiff.AddedFields, want) {
+		t.Errorf("AddedFields = %v, want %v", podDiff.AddedFields, want)
+	}
+	if want := []string{"status"}; !reflect.DeepEqual(podDiff.RemovedFields, want) {
+		t.Errorf("RemovedFields = %v, want %v", podDiff.RemovedFields, want)
+	}
+	if want := (TypeChange{OldType: "object", NewType: "string"}); podDiff.ChangedTypes["spec"] != want {
+		t.Errorf("ChangedTypes[spec] = %+v, want %+v", podDiff.ChangedTypes["spec"], want)
+	}
+	if want := []string{"metadata"}; !reflect.DeepEqual(podDiff.AddedRequired, want) {
+		t.Errorf("AddedRequired = %v, want %v", podDiff.AddedRequired, want)
+	}
+
+	wantDeprecated := []string{"metadata"}
+	if !reflect.DeepEqual(report.NewlyDeprecatedFields["Pod v1 core"], wantDeprecated) {
+		t.Errorf("NewlyDeprecatedFields[Pod v1 core] = %v, want %v", report.NewlyDeprecatedFields["Pod v1 core"], wantDeprecated)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+// DocWriter is implemented once per output format. The generator entrypoint
+// walks the loaded spec exactly once, calling these methods in a fixed
+// order (overview, API groups, resource categories, resources/operations,
+// definitions, old versions, finalize); each implementation is responsible
+// for turning that sequence into a complete document in its own format.
+type DocWriter interface {
+	WriteOverview() error
+	WriteAPIGroupVersions(gvs api.GroupVersions) error
+	WriteResourceCategory(name, file string) error
+	WriteDefinitionsOverview() error
+	WriteOrphanedOperationsOverview() error
+	WriteDefinition(d *api.Definition) error
+	WriteOperation(o *api.Operation) error
+	WriteResource(r *api.Resource) error
+	WriteOldVersionsOverview() error
+	Finalize() error
+}
+
+// Format identifies one of the output formats a DocWriter can produce.
+type Format string
+
+const (
+	FormatHTML     Format = "html"
+	FormatMarkdown Format = "markdown"
+	FormatAsciiDoc Format = "asciidoc"
+)
+
+// NewDocWriter constructs the DocWriter for the given format, defaulting
+// to FormatHTML when format is empty, and erroring on anything else.
+func NewDocWriter(format Format, config *api.Config, title string) (DocWriter, error) {
+	switch format {
+	case "", FormatHTML:
+		return NewHTMLWriter(config, title)
+	case FormatMarkdown:
+		return NewMarkdownWriter(config, title)
+	case FormatAsciiDoc:
+		return NewAsciiDocWriter(config, title)
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+func TestNewDocWriter(t *testing.T) {
+	config := &api.Config{SpecVersion: "v1.30.0"}
+
+	cases := []struct {
+		format Format
+		want   any
+	}{
+		{"", &HTMLWriter{}},
+		{FormatHTML, &HTMLWriter{}},
+		{FormatMarkdown, &MarkdownWriter{}},
+		{FormatAsciiDoc, &AsciiDocWriter{}},
+	}
+
+	for _, c := range cases {
+		writer, err := NewDocWriter(c.format, config, "Test")
+		if err != nil {
+			t.Fatalf("NewDocWriter(%q) error = %v", c.format, err)
+		}
+
+		switch c.want.(type) {
+		case *HTMLWriter:
+			if _, ok := writer.(*HTMLWriter); !ok {
+				t.Errorf("NewDocWriter(%q) = %T, want *HTMLWriter", c.format, writer)
+			}
+		case *MarkdownWriter:
+			if _, ok := writer.(*MarkdownWriter); !ok {
+				t.Errorf("NewDocWriter(%q) = %T, want *MarkdownWriter", c.format, writer)
+			}
+		case *AsciiDocWriter:
+			if _, ok := writer.(*AsciiDocWriter); !ok {
+				t.Errorf("NewDocWriter(%q) = %T, want *AsciiDocWriter", c.format, writer)
+			}
+		}
+	}
+
+	if _, err := NewDocWriter("pdf", config, "Test"); err == nil {
+		t.Error("NewDocWriter(\"pdf\") error = nil, want error for unknown format")
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+// BundleName returns the output directory name used for a single
+// GroupVersion bundle when docs are generated per-GV (one output bundle
+// per entry in a Kubernetes 1.23+ /openapi/v3 index) rather than from one
+// merged swagger.json, e.g. "apps_v1" for the apps/v1 group version or
+// "v1" for the core group.
+func BundleName(group api.ApiGroup, version api.ApiVersion) string {
+	g := strings.ReplaceAll(strings.ToLower(group.String()), ".", "-")
+	v := strings.ToLower(version.String())
+
+	if g == "" {
+		return v
+	}
+
+	return g + "_" + v
+}
+
+// BuildBundles loads one *api.Config per GroupVersion source makes
+// available, keyed by the BundleName of its GroupVersion, so a caller
+// generating docs from a Kubernetes 1.23+ /openapi/v3 index (one document
+// per GV rather than a single merged swagger.json) can hand each bundle
+// to its own DocWriter output directory.
+//
+// BuildBundles holds every bundle's *api.Config in memory at once; for a
+// large GV index, GenerateBundleDocs renders one bundle at a time instead.
+func BuildBundles(specVersion string, source SpecSource) (map[string]*api.Config, error) {
+	gvs, err := source.Index()
+	if err != nil {
+		return nil, fmt.Errorf("indexing spec source: %w", err)
+	}
+
+	bundles := map[string]*api.Config{}
+
+	for groupName, versions := range gvs {
+		group := api.ApiGroup(groupName)
+
+		for _, version := range versions {
+			name := BundleName(group, version)
+
+			raw, err := source.Fetch(group, version)
+			if err != nil {
+				return nil, fmt.Errorf("fetching bundle %q: %w", name, err)
+			}
+
+			config, err := LoadConfig(specVersion, raw)
+			if err != nil {
+				return nil, fmt.Errorf("loading bundle %q: %w", name, err)
+			}
+
+			bundles[name] = config
+		}
+	}
+
+	return bundles, nil
+}
+
+// GenerateBundleDocs lazily loads and renders one per-GV bundle at a time
+// — unlike BuildBundles, which keeps every bundle's *api.Config resident
+// at once — so generating docs from a large GV index doesn't hold more
+// than one bundle's parsed definitions in memory at a time. newWriter
+// constructs the DocWriter each bundle's content is rendered through,
+// keyed by its BundleName. It returns the bundle names written, in the
+// order they were rendered (groups sorted with the core group first, then
+// each group's versions sorted), for GenerateBundleIndex to link to.
+func GenerateBundleDocs(source SpecSource, specVersion string, newWriter func(bundleName string) (DocWriter, error)) ([]string, error) {
+	gvs, err := source.Index()
+	if err != nil {
+		return nil, fmt.Errorf("indexing spec source: %w", err)
+	}
+
+	var names []string
+
+	groups := make(api.ApiGroups, 0, len(gvs))
+	for groupName := range gvs {
+		groups = append(groups, api.ApiGroup(groupName))
+	}
+	sort.Sort(groups)
+
+	for _, group := range groups {
+		versions := gvs[group.String()]
+		sort.Sort(versions)
+
+		for _, version := range versions {
+			name := BundleName(group, version)
+
+			raw, err := source.Fetch(group, version)
+			if err != nil {
+				return nil, fmt.Errorf("fetching bundle %q: %w", name, err)
+			}
+
+			config, err := LoadConfig(specVersion, raw)
+			if err != nil {
+				return nil, fmt.Errorf("loading bundle %q: %w", name, err)
+			}
+
+			writer, err := newWriter(name)
+			if err != nil {
+				return nil, fmt.Errorf("creating writer for bundle %q: %w", name, err)
+			}
+
+			if err := writeBundle(writer, config); err != nil {
+				return nil, fmt.Errorf("writing bundle %q: %w", name, err)
+			}
+
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// GenerateBundleIndex renders the cross-GV index page linking to every
+// bundle GenerateBundleDocs produced, the entry point a reader lands on
+// before picking a GroupVersion to read, the same way a merged-swagger
+// build's "API Groups" page links to each group's resources.
+func GenerateBundleIndex(h *HTMLWriter, bundleNames []string) error {
+	names := append([]string(nil), bundleNames...)
+	sort.Strings(names)
+
+	return h.WriteBundleIndex(names)
+}
+
+// WriteBundleIndex renders the page GenerateBundleIndex builds.
+func (h *HTMLWriter) WriteBundleIndex(bundleNames []string) error {
+	content, err := renderTemplate("bundle-index.html", bundleNames)
+	if err != nil {
+		return err
+	}
+
+	filename := "_bundle_index.html"
+	if err := writeStaticFile(filename, content); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level:      1,
+		Title:      "BUNDLES",
+		PlainTitle: "Bundles",
+		Link:       "bundles",
+		File:       filename,
+	}
+	h.TOC.Sections = append(h.TOC.Sections, &item)
+	h.currentTOCItem = &item
+
+	return nil
+}
+
+// writeBundle drives a single bundle's DocWriter through overview,
+// definitions, resources and finalize, in that order. It writes no API
+// groups, resource category, operations or old versions sections.
+func writeBundle(w DocWriter, config *api.Config) error {
+	if err := w.WriteOverview(); err != nil {
+		return err
+	}
+
+	for _, d := range config.Definitions {
+		if err := w.WriteDefinition(d); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range config.Resources {
+		if err := w.WriteResource(r); err != nil {
+			return err
+		}
+	}
+
+	return w.Finalize()
+}
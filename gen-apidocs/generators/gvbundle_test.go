@@ -0,0 +1,159 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+func TestBundleName(t *testing.T) {
+	cases := []struct {
+		group   api.ApiGroup
+		version api.ApiVersion
+		want    string
+	}{
+		{"", "v1", "v1"},
+		{"apps", "v1", "apps_v1"},
+		{"flowcontrol.apiserver.k8s.io", "v1beta3", "flowcontrol-apiserver-k8s-io_v1beta3"},
+	}
+
+	for _, c := range cases {
+		if got := BundleName(c.group, c.version); got != c.want {
+			t.Errorf("BundleName(%q, %q) = %q, want %q", c.group, c.version, got, c.want)
+		}
+	}
+}
+
+func TestBuildBundles(t *testing.T) {
+	coreV1 := []byte(`{
+		"swagger": "2.0",
+		"definitions": {"io.k8s.api.core.v1.Pod": {"description": "Pod is a collection of containers."}}
+	}`)
+	appsV1 := []byte(`{
+		"swagger": "2.0",
+		"definitions": {"io.k8s.api.apps.v1.Deployment": {"description": "Deployment enables declarative updates."}}
+	}`)
+
+	source := MapSource{Docs: map[api.ApiGroup]map[api.ApiVersion][]byte{
+		"":     {"v1": coreV1},
+		"apps": {"v1": appsV1},
+	}}
+
+	bundles, err := BuildBundles("v1.30.0", source)
+	if err != nil {
+		t.Fatalf("BuildBundles() error = %v", err)
+	}
+
+	if len(bundles) != 2 {
+		t.Fatalf("len(bundles) = %d, want 2", len(bundles))
+	}
+
+	core, ok := bundles["v1"]
+	if !ok {
+		t.Fatal(`bundles["v1"] missing`)
+	}
+	if len(core.Definitions) != 1 || core.Definitions[0].Name != "io.k8s.api.core.v1.Pod" {
+		t.Errorf("bundles[%q].Definitions = %+v", "v1", core.Definitions)
+	}
+
+	apps, ok := bundles["apps_v1"]
+	if !ok {
+		t.Fatal(`bundles["apps_v1"] missing`)
+	}
+	if len(apps.Definitions) != 1 || apps.Definitions[0].Name != "io.k8s.api.apps.v1.Deployment" {
+		t.Errorf("bundles[%q].Definitions = %+v", "apps_v1", apps.Definitions)
+	}
+}
+
+func TestBuildBundlesPropagatesLoadError(t *testing.T) {
+	source := MapSource{Docs: map[api.ApiGroup]map[api.ApiVersion][]byte{
+		"apps": {"v1": []byte(`not json`)},
+	}}
+
+	if _, err := BuildBundles("v1.30.0", source); err == nil {
+		t.Fatal("BuildBundles() error = nil, want error for invalid spec document")
+	}
+}
+
+// stubWriter is a no-op DocWriter recording which methods were called, for
+// tests that only need to assert GenerateBundleDocs' call sequence rather
+// than a real writer's file output.
+type stubWriter struct {
+	definitions []string
+	finalized   bool
+}
+
+func (w *stubWriter) WriteOverview() error                          { return nil }
+func (w *stubWriter) WriteAPIGroupVersions(api.GroupVersions) error { return nil }
+func (w *stubWriter) WriteResourceCategory(name, file string) error { return nil }
+func (w *stubWriter) WriteDefinitionsOverview() error               { return nil }
+func (w *stubWriter) WriteOrphanedOperationsOverview() error        { return nil }
+func (w *stubWriter) WriteOperation(o *api.Operation) error         { return nil }
+func (w *stubWriter) WriteOldVersionsOverview() error               { return nil }
+func (w *stubWriter) WriteResource(r *api.Resource) error           { return nil }
+
+func (w *stubWriter) WriteDefinition(d *api.Definition) error {
+	w.definitions = append(w.definitions, d.Name)
+	return nil
+}
+
+func (w *stubWriter) Finalize() error {
+	w.finalized = true
+	return nil
+}
+
+func TestGenerateBundleDocs(t *testing.T) {
+	source := MapSource{Docs: map[api.ApiGroup]map[api.ApiVersion][]byte{
+		"": {"v1": []byte(`{
+			"swagger": "2.0",
+			"definitions": {"io.k8s.api.core.v1.Pod": {"description": "Pod is a collection of containers."}}
+		}`)},
+		"apps": {"v1": []byte(`{
+			"swagger": "2.0",
+			"definitions": {"io.k8s.api.apps.v1.Deployment": {"description": "Deployment enables declarative updates."}}
+		}`)},
+	}}
+
+	writers := map[string]*stubWriter{}
+
+	names, err := GenerateBundleDocs(source, "v1.30.0", func(bundleName string) (DocWriter, error) {
+		w := &stubWriter{}
+		writers[bundleName] = w
+		return w, nil
+	})
+	if err != nil {
+		t.Fatalf("GenerateBundleDocs() error = %v", err)
+	}
+
+	wantNames := []string{"v1", "apps_v1"}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("GenerateBundleDocs() names = %v, want %v", names, wantNames)
+	}
+
+	core, ok := writers["v1"]
+	if !ok || len(core.definitions) != 1 || core.definitions[0] != "io.k8s.api.core.v1.Pod" || !core.finalized {
+		t.Errorf(`writers["v1"] = %+v`, core)
+	}
+
+	apps, ok := writers["apps_v1"]
+	if !ok || len(apps.definitions) != 1 || apps.definitions[0] != "io.k8s.api.apps.v1.Deployment" || !apps.finalized {
+		t.Errorf(`writers["apps_v1"] = %+v`, apps)
+	}
+}
@@ -19,7 +19,6 @@ package generators
 import (
 	"fmt"
 	"html/template"
-	"log"
 	"os"
 	"path/filepath"
 	"sort"
@@ -28,14 +27,6 @@ import (
 	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
 )
 
-type TOCItem struct {
-	Level       int
-	Title       template.HTML
-	Link        string
-	File        string
-	SubSections []*TOCItem
-}
-
 func (ti *TOCItem) ToHTML() template.HTML {
 	rendered, err := renderTemplate("toc-item.html", ti)
 	if err != nil {
@@ -45,15 +36,14 @@ func (ti *TOCItem) ToHTML() template.HTML {
 	return rendered
 }
 
-type TOC struct {
-	Title    string
-	Sections []*TOCItem
-}
-
 type HTMLWriter struct {
 	Config *api.Config
 	TOC    TOC
 
+	// SearchIndexer builds search-index.json from the collected TOC.
+	// Left nil, it defaults to JSONSearchIndexer.
+	SearchIndexer SearchIndexer
+
 	// currentTOCItem is used to remember the current item between
 	// calls to e.g. WriteResourceCategory() followed by WriteResource().
 	currentTOCItem *TOCItem
@@ -78,10 +68,11 @@ func (h *HTMLWriter) WriteOverview() error {
 	}
 
 	item := TOCItem{
-		Level: 1,
-		Title: "Overview",
-		Link:  "api-overview",
-		File:  filename,
+		Level:      1,
+		Title:      "Overview",
+		PlainTitle: "Overview",
+		Link:       "api-overview",
+		File:       filename,
 	}
 	h.TOC.Sections = append(h.TOC.Sections, &item)
 	h.currentTOCItem = &item
@@ -127,10 +118,11 @@ func (h *HTMLWriter) WriteAPIGroupVersions(gvs api.GroupVersions) error {
 	}
 
 	item := TOCItem{
-		Level: 1,
-		Title: "API Groups",
-		Link:  "api-groups",
-		File:  fn,
+		Level:      1,
+		Title:      "API Groups",
+		PlainTitle: "API Groups",
+		Link:       "api-groups",
+		File:       fn,
 	}
 	h.TOC.Sections = append(h.TOC.Sections, &item)
 	h.currentTOCItem = &item
@@ -145,10 +137,11 @@ func (h *HTMLWriter) WriteResourceCategory(name, file string) error {
 
 	link := strings.ReplaceAll(strings.ToLower(name), " ", "-")
 	item := TOCItem{
-		Level: 1,
-		Title: template.HTML(name),
-		Link:  link,
-		File:  "_" + file + ".html",
+		Level:      1,
+		Title:      template.HTML(name),
+		PlainTitle: name,
+		Link:       link,
+		File:       "_" + file + ".html",
 	}
 	h.TOC.Sections = append(h.TOC.Sections, &item)
 	h.currentTOCItem = &item
@@ -183,10 +176,11 @@ func (h *HTMLWriter) WriteDefinitionsOverview() error {
 	}
 
 	item := TOCItem{
-		Level: 1,
-		Title: "DEFINITIONS",
-		Link:  "definitions",
-		File:  "_definitions.html",
+		Level:      1,
+		Title:      "DEFINITIONS",
+		PlainTitle: "Definitions",
+		Link:       "definitions",
+		File:       "_definitions.html",
 	}
 	h.TOC.Sections = append(h.TOC.Sections, &item)
 	h.currentTOCItem = &item
@@ -200,10 +194,11 @@ func (h *HTMLWriter) WriteOrphanedOperationsOverview() error {
 	}
 
 	item := TOCItem{
-		Level: 1,
-		Title: "OPERATIONS",
-		Link:  "operations",
-		File:  "_operations.html",
+		Level:      1,
+		Title:      "OPERATIONS",
+		PlainTitle: "Operations",
+		Link:       "operations",
+		File:       "_operations.html",
 	}
 	h.TOC.Sections = append(h.TOC.Sections, &item)
 	h.currentTOCItem = &item
@@ -212,7 +207,7 @@ func (h *HTMLWriter) WriteOrphanedOperationsOverview() error {
 }
 
 func (h *HTMLWriter) WriteDefinition(d *api.Definition) error {
-	fn := definitionFileName(d)
+	fn := definitionFileName(d, ".html")
 	path := filepath.Join(api.IncludesDir, fn)
 	f, err := os.Create(path)
 	if err != nil {
@@ -228,22 +223,26 @@ func (h *HTMLWriter) WriteDefinition(d *api.Definition) error {
 	// all the individual definition files, but definitions will not show up
 	// in the nav treet because it would take up too much screen estate.
 	item := TOCItem{
-		Level: 2,
-		Title: title,
-		Link:  linkID,
-		File:  fn,
+		Level:      2,
+		Title:      title,
+		PlainTitle: nvg,
+		Link:       linkID,
+		File:       fn,
+		Group:      d.GroupDisplayName(),
+		Version:    d.Version.String(),
 	}
 	h.currentTOCItem.SubSections = append(h.currentTOCItem.SubSections, &item)
 
 	return renderTemplateTo(f, "definition.html", map[string]any{
-		"nvg":        title,
-		"linkID":     linkID,
-		"definition": d,
+		"nvg":         title,
+		"linkID":      linkID,
+		"definition":  d,
+		"validations": FormatCELValidations(d.CELValidations),
 	})
 }
 
 func (h *HTMLWriter) WriteOperation(o *api.Operation) error {
-	fn := operationFileName(o)
+	fn := operationFileName(o, ".html")
 	path := filepath.Join(api.IncludesDir, fn)
 	f, err := os.Create(path)
 	if err != nil {
@@ -275,10 +274,13 @@ func (h *HTMLWriter) WriteOperation(o *api.Operation) error {
 	}
 
 	item := TOCItem{
-		Level: 2,
-		Title: title,
-		Link:  linkID,
-		File:  fn,
+		Level:      2,
+		Title:      title,
+		PlainTitle: nvg,
+		Link:       linkID,
+		File:       fn,
+		Group:      oGroup,
+		Version:    oVersion,
 	}
 	h.currentTOCItem.SubSections = append(h.currentTOCItem.SubSections, &item)
 
@@ -286,7 +288,7 @@ func (h *HTMLWriter) WriteOperation(o *api.Operation) error {
 }
 
 func (h *HTMLWriter) WriteResource(r *api.Resource) error {
-	filename := conceptFileName(r.Definition)
+	filename := conceptFileName(r.Definition, ".html")
 	path := filepath.Join(api.IncludesDir, filename)
 
 	w, err := os.Create(path)
@@ -299,33 +301,18 @@ func (h *HTMLWriter) WriteResource(r *api.Resource) error {
 	linkID := getLink(dvg)
 
 	resourceItem := TOCItem{
-		Level: 2,
-		Title: h.gvkMarkup(r.Definition.GroupDisplayName(), r.Definition.Version, r.Name),
-		Link:  linkID,
-		File:  filename,
+		Level:      2,
+		Title:      h.gvkMarkup(r.Definition.GroupDisplayName(), r.Definition.Version, r.Name),
+		PlainTitle: dvg,
+		Link:       linkID,
+		File:       filename,
+		Group:      r.Definition.GroupDisplayName(),
+		Version:    r.Definition.Version.String(),
 	}
 	h.currentTOCItem.SubSections = append(h.currentTOCItem.SubSections, &resourceItem)
 
-	for _, oc := range r.Definition.OperationCategories {
-		if len(oc.Operations) == 0 {
-			continue
-		}
-
-		ocItem := TOCItem{
-			Level: 3,
-			Title: template.HTML(oc.Name),
-			Link:  oc.TocID(r.Definition),
-		}
-		resourceItem.SubSections = append(resourceItem.SubSections, &ocItem)
-
-		for _, o := range oc.Operations {
-			ocItem.SubSections = append(ocItem.SubSections, &TOCItem{
-				Level: 4,
-				Title: template.HTML(o.Type.Name),
-				Link:  o.TocID(r.Definition),
-			})
-		}
-	}
+	resourceItem.SubSections = append(resourceItem.SubSections,
+		operationCategoryTOCItems(r.Definition, func(name string) template.HTML { return template.HTML(name) })...)
 
 	if err := renderTemplateTo(w, "resource.html", map[string]any{
 		"resource": r,
@@ -344,10 +331,11 @@ func (h *HTMLWriter) WriteOldVersionsOverview() error {
 	}
 
 	item := TOCItem{
-		Level: 1,
-		Title: "OLD API VERSIONS",
-		Link:  "old-api-versions",
-		File:  "_oldversions.html",
+		Level:      1,
+		Title:      "OLD API VERSIONS",
+		PlainTitle: "Old API Versions",
+		Link:       "old-api-versions",
+		File:       "_oldversions.html",
 	}
 	h.TOC.Sections = append(h.TOC.Sections, &item)
 	h.currentTOCItem = &item
@@ -364,7 +352,7 @@ func (h *HTMLWriter) Finalize() error {
 		return err
 	}
 
-	return nil
+	return h.writeSearchIndex()
 }
 
 func (h *HTMLWriter) generateIndex() error {
@@ -375,34 +363,7 @@ func (h *HTMLWriter) generateIndex() error {
 	defer html.Close()
 
 	// collect content from all the individual files we just created
-	var content strings.Builder
-
-	collect := func(filename string) {
-		fileContent, err := os.ReadFile(filepath.Join(api.IncludesDir, filename))
-		if err == nil {
-			content.Write(fileContent)
-			log.Printf("Collecting %s… \033[32mOK\033[0m", filename)
-		} else {
-			log.Printf("Collecting %s… \033[31mNot found\033[0m", filename)
-		}
-	}
-
-	// TODO: Make this a recursive function.
-	for _, sec := range h.TOC.Sections {
-		collect(sec.File)
-
-		for _, sub := range sec.SubSections {
-			if len(sub.File) > 0 {
-				collect(sub.File)
-			}
-
-			for _, subsub := range sub.SubSections {
-				if len(subsub.File) > 0 {
-					collect(subsub.File)
-				}
-			}
-		}
-	}
+	content := collectTOCContent(h.TOC.Sections, true)
 
 	pos := strings.LastIndex(h.Config.SpecVersion, ".")
 	release := fmt.Sprintf("release-%s", h.Config.SpecVersion[1:pos])
@@ -412,7 +373,7 @@ func (h *HTMLWriter) generateIndex() error {
 		"toc":      h.TOC,
 		"config":   h.Config,
 		"specLink": specLink,
-		"content":  template.HTML(content.String()),
+		"content":  template.HTML(content),
 	})
 }
 
@@ -0,0 +1,349 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/spec"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+// LoadConfig decodes a raw spec document into an *api.Config, dispatching
+// on DetectSpecFormat so Swagger 2.0 and OpenAPI v3 documents — including
+// the per-GV documents Kubernetes has published at /openapi/v3 since
+// 1.23, and the v3-only schemas many CRDs ship — are both understood
+// instead of the latter being silently dropped by a Swagger-2.0-only
+// parser.
+func LoadConfig(specVersion string, raw []byte) (*api.Config, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("decoding spec document: %w", err)
+	}
+
+	if DetectSpecFormat(doc) == SpecFormatOpenAPIv3 {
+		return loadOpenAPIv3(specVersion, doc), nil
+	}
+
+	return loadSwagger2(specVersion, raw)
+}
+
+func loadSwagger2(specVersion string, raw []byte) (*api.Config, error) {
+	document, err := loads.Analyzed(raw, "2.0")
+	if err != nil {
+		return nil, fmt.Errorf("parsing swagger 2.0 document: %w", err)
+	}
+
+	config := &api.Config{SpecVersion: specVersion}
+
+	names := make([]string, 0, len(document.Spec().Definitions))
+	for name := range document.Spec().Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		schema := document.Spec().Definitions[name]
+		config.Definitions = append(config.Definitions, &api.Definition{
+			Name:        name,
+			Description: schema.Description,
+			Properties:  propertiesFromSwagger2Schema(schema),
+			Required:    schema.Required,
+		})
+	}
+
+	return config, nil
+}
+
+// propertiesFromSwagger2Schema builds the field-name-keyed map
+// BuildDiffReport compares two spec versions field by field with.
+func propertiesFromSwagger2Schema(schema spec.Schema) map[string]api.Property {
+	if len(schema.Properties) == 0 {
+		return nil
+	}
+
+	properties := make(map[string]api.Property, len(schema.Properties))
+	for name, prop := range schema.Properties {
+		// Swagger 2.0 schemas have no `deprecated` marker (that's an
+		// OpenAPI v3 addition), so Deprecated is left false here.
+		properties[name] = api.Property{
+			Type:        strings.Join(prop.Type, ","),
+			Description: prop.Description,
+		}
+	}
+
+	return properties
+}
+
+func loadOpenAPIv3(specVersion string, doc map[string]any) *api.Config {
+	config := &api.Config{SpecVersion: specVersion}
+
+	components, _ := doc["components"].(map[string]any)
+	schemas, _ := components["schemas"].(map[string]any)
+
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		schema, ok := schemas[name].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		config.Definitions = append(config.Definitions, definitionFromV3Schema(name, schema))
+	}
+
+	config.Operations = operationsFromV3Paths(doc)
+
+	return config
+}
+
+// definitionFromV3Schema translates a single components.schemas entry,
+// resolving the oneOf/anyOf branches and discriminator the Swagger 2.0
+// path never had to handle.
+func definitionFromV3Schema(name string, schema map[string]any) *api.Definition {
+	d := &api.Definition{Name: name}
+
+	if description, ok := schema["description"].(string); ok {
+		d.Description = description
+	}
+
+	d.OneOf = refNamesFromPolymorphicKeys(schema)
+
+	if discriminator, ok := schema["discriminator"].(map[string]any); ok {
+		disc := &api.Discriminator{}
+
+		if propertyName, ok := discriminator["propertyName"].(string); ok {
+			disc.PropertyName = propertyName
+		}
+
+		if mapping, ok := discriminator["mapping"].(map[string]any); ok {
+			disc.Mapping = map[string]string{}
+			for k, v := range mapping {
+				if s, ok := v.(string); ok {
+					disc.Mapping[k] = s
+				}
+			}
+		}
+
+		d.Discriminator = disc
+	}
+
+	d.CELValidations = celValidationsFromSchema(schema)
+	d.Properties = propertiesFromV3Schema(schema)
+
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			if s, ok := r.(string); ok {
+				d.Required = append(d.Required, s)
+			}
+		}
+	}
+
+	return d
+}
+
+// propertiesFromV3Schema builds the field-name-keyed map
+// BuildDiffReport compares two spec versions field by field with.
+func propertiesFromV3Schema(schema map[string]any) map[string]api.Property {
+	raw, ok := schema["properties"].(map[string]any)
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+
+	properties := make(map[string]api.Property, len(raw))
+
+	for name, propSchema := range raw {
+		prop, ok := propSchema.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		p := api.Property{}
+		p.Type, _ = prop["type"].(string)
+		p.Description, _ = prop["description"].(string)
+		p.Deprecated, _ = prop["deprecated"].(bool)
+		p.CELValidations = celValidationsFromSchema(prop)
+		p.PreserveUnknownFields, _ = prop["x-kubernetes-preserve-unknown-fields"].(bool)
+		p.IntOrString, _ = prop["x-kubernetes-int-or-string"].(bool)
+		p.ListType, _ = prop["x-kubernetes-list-type"].(string)
+		properties[name] = p
+	}
+
+	return properties
+}
+
+// celValidationsFromSchema extracts a CRD field's x-kubernetes-validations
+// rules, e.g. a Deployment's spec.replicas carrying a "self >= 0" rule.
+func celValidationsFromSchema(schema map[string]any) []api.CELValidation {
+	raw, ok := schema["x-kubernetes-validations"].([]any)
+	if !ok {
+		return nil
+	}
+
+	validations := make([]api.CELValidation, 0, len(raw))
+
+	for _, entry := range raw {
+		m, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		v := api.CELValidation{}
+		v.Rule, _ = m["rule"].(string)
+		v.Message, _ = m["message"].(string)
+		v.Reason, _ = m["reason"].(string)
+
+		if v.Rule != "" {
+			validations = append(validations, v)
+		}
+	}
+
+	return validations
+}
+
+// refNamesFromPolymorphicKeys extracts the definition names a
+// oneOf/anyOf/allOf schema resolves to, e.g. IntOrString's
+// ["io.k8s...string", "io.k8s...integer"] for a oneOf, or the embedded
+// types a CRD's allOf composition refers to.
+func refNamesFromPolymorphicKeys(schema map[string]any) []string {
+	var names []string
+
+	for _, key := range []string{"oneOf", "anyOf", "allOf"} {
+		branches, ok := schema[key].([]any)
+		if !ok {
+			continue
+		}
+
+		for _, branch := range branches {
+			m, ok := branch.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if ref, ok := m["$ref"].(string); ok {
+				names = append(names, refName(ref))
+			}
+		}
+	}
+
+	return names
+}
+
+// refName returns the definition name a "#/components/schemas/Foo" (or
+// legacy Swagger 2.0 "#/definitions/Foo") $ref points at.
+func refName(ref string) string {
+	for _, prefix := range []string{"#/components/schemas/", "#/definitions/"} {
+		if strings.HasPrefix(ref, prefix) {
+			return strings.TrimPrefix(ref, prefix)
+		}
+	}
+
+	return ref
+}
+
+var httpMethods = []string{"get", "post", "put", "patch", "delete"}
+
+// operationsFromV3Paths walks an OpenAPI v3 document's `paths` for every
+// operation with an operationId, capturing the requestBody content types
+// a Swagger-2.0-only loader has no equivalent field for. Operations are
+// returned flat and stored in Config.Operations as orphaned; they are not
+// grouped under any Resource's OperationCategories.
+func operationsFromV3Paths(doc map[string]any) []*api.Operation {
+	paths, _ := doc["paths"].(map[string]any)
+
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	var ops []*api.Operation
+
+	for _, path := range pathNames {
+		pathItem, ok := paths[path].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for _, method := range httpMethods {
+			rawOp, ok := pathItem[method].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			id, _ := rawOp["operationId"].(string)
+			if id == "" {
+				continue
+			}
+
+			ops = append(ops, &api.Operation{
+				ID:      id,
+				Content: contentTypesFromRequestBody(rawOp["requestBody"]),
+			})
+		}
+	}
+
+	return ops
+}
+
+// contentTypesFromRequestBody extracts the media types (and, where
+// present, the schema the body is expected to match) an OpenAPI v3
+// requestBody accepts, e.g. "application/json" for a JSON PUT/POST body,
+// or "application/apply-patch+yaml" for server-side apply.
+func contentTypesFromRequestBody(raw any) []api.ContentType {
+	requestBody, ok := raw.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	content, ok := requestBody["content"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+
+	types := make([]api.ContentType, 0, len(mediaTypes))
+	for _, mediaType := range mediaTypes {
+		ct := api.ContentType{MediaType: mediaType}
+
+		if entry, ok := content[mediaType].(map[string]any); ok {
+			if schema, ok := entry["schema"].(map[string]any); ok {
+				if ref, ok := schema["$ref"].(string); ok {
+					ct.Schema = refName(ref)
+				}
+			}
+		}
+
+		types = append(types, ct)
+	}
+
+	return types
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+func TestDetectSpecFormat(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  map[string]any
+		want SpecFormat
+	}{
+		{"swagger 2.0", map[string]any{"swagger": "2.0"}, SpecFormatSwagger2},
+		{"openapi 3.0", map[string]any{"openapi": "3.0.3"}, SpecFormatOpenAPIv3},
+		{"openapi 3.1", map[string]any{"openapi": "3.1.0"}, SpecFormatOpenAPIv3},
+		{"no version marker", map[string]any{}, SpecFormatSwagger2},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := DetectSpecFormat(c.raw); got != c.want {
+				t.Errorf("DetectSpecFormat(%v) = %v, want %v", c.raw, got, c.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigSwagger2(t *testing.T) {
+	raw := []byte(`{
+		"swagger": "2.0",
+		"info": {"title": "test", "version": "v1"},
+		"paths": {},
+		"definitions": {
+			"io.k8s.api.core.v1.Pod": {"description": "Pod is a collection of containers."}
+		}
+	}`)
+
+	config, err := LoadConfig("v1.30.0", raw)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if len(config.Definitions) != 1 {
+		t.Fatalf("len(config.Definitions) = %d, want 1", len(config.Definitions))
+	}
+	if config.Definitions[0].Name != "io.k8s.api.core.v1.Pod" {
+		t.Errorf("Definitions[0].Name = %q", config.Definitions[0].Name)
+	}
+	if config.Definitions[0].Description != "Pod is a collection of containers." {
+		t.Errorf("Definitions[0].Description = %q", config.Definitions[0].Description)
+	}
+}
+
+func TestLoadConfigOpenAPIv3(t *testing.T) {
+	raw := []byte(`{
+		"openapi": "3.0.0",
+		"info": {"title": "test", "version": "v1"},
+		"paths": {
+			"/api/v1/namespaces/{namespace}/pods": {
+				"post": {
+					"operationId": "createCoreV1NamespacedPod",
+					"requestBody": {
+						"content": {
+							"application/json": {"schema": {"$ref": "#/components/schemas/io.k8s.api.core.v1.Pod"}},
+							"application/yaml": {}
+						}
+					}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"io.k8s.apimachinery.pkg.util.intstr.IntOrString": {
+					"oneOf": [
+						{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.util.intstr.int"},
+						{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.util.intstr.string"}
+					],
+					"discriminator": {"propertyName": "type"},
+					"x-kubernetes-validations": [
+						{"rule": "self >= 0", "message": "must not be negative"},
+						{"rule": "self.size() > 0"}
+					]
+				}
+			}
+		}
+	}`)
+
+	config, err := LoadConfig("v1.30.0", raw)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if len(config.Definitions) != 1 {
+		t.Fatalf("len(config.Definitions) = %d, want 1", len(config.Definitions))
+	}
+
+	d := config.Definitions[0]
+	wantOneOf := []string{
+		"io.k8s.apimachinery.pkg.util.intstr.int",
+		"io.k8s.apimachinery.pkg.util.intstr.string",
+	}
+	if !reflect.DeepEqual(d.OneOf, wantOneOf) {
+		t.Errorf("Definitions[0].OneOf = %v, want %v", d.OneOf, wantOneOf)
+	}
+	if d.Discriminator == nil || d.Discriminator.PropertyName != "type" {
+		t.Errorf("Definitions[0].Discriminator = %+v", d.Discriminator)
+	}
+
+	wantCELValidations := []api.CELValidation{
+		{Rule: "self >= 0", Message: "must not be negative"},
+		{Rule: "self.size() > 0"},
+	}
+	if !reflect.DeepEqual(d.CELValidations, wantCELValidations) {
+		t.Errorf("Definitions[0].CELValidations = %+v, want %+v", d.CELValidations, wantCELValidations)
+	}
+
+	if len(config.Operations) != 1 {
+		t.Fatalf("len(config.Operations) = %d, want 1", len(config.Operations))
+	}
+
+	op := config.Operations[0]
+	if op.ID != "createCoreV1NamespacedPod" {
+		t.Errorf("Operations[0].ID = %q", op.ID)
+	}
+
+	wantContent := []string{"application/json", "application/yaml"}
+	gotContent := make([]string, len(op.Content))
+	for i, ct := range op.Content {
+		gotContent[i] = ct.MediaType
+	}
+	if !reflect.DeepEqual(gotContent, wantContent) {
+		t.Errorf("Operations[0].Content media types = %v, want %v", gotContent, wantContent)
+	}
+	if op.Content[0].Schema != "io.k8s.api.core.v1.Pod" {
+		t.Errorf("Operations[0].Content[0].Schema = %q", op.Content[0].Schema)
+	}
+}
+
+func TestRefNamesFromPolymorphicKeys(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema map[string]any
+		want   []string
+	}{
+		{
+			"oneOf",
+			map[string]any{"oneOf": []any{
+				map[string]any{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.util.intstr.int"},
+				map[string]any{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.util.intstr.string"},
+			}},
+			[]string{"io.k8s.apimachinery.pkg.util.intstr.int", "io.k8s.apimachinery.pkg.util.intstr.string"},
+		},
+		{
+			"anyOf",
+			map[string]any{"anyOf": []any{
+				map[string]any{"$ref": "#/components/schemas/io.k8s.api.core.v1.Volume"},
+			}},
+			[]string{"io.k8s.api.core.v1.Volume"},
+		},
+		{
+			"allOf",
+			map[string]any{"allOf": []any{
+				map[string]any{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"},
+				map[string]any{"$ref": "#/components/schemas/io.k8s.api.core.v1.PodSpec"},
+			}},
+			[]string{"io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta", "io.k8s.api.core.v1.PodSpec"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := refNamesFromPolymorphicKeys(c.schema); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("refNamesFromPolymorphicKeys(%v) = %v, want %v", c.name, got, c.want)
+			}
+		})
+	}
+}
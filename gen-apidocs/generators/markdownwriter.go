@@ -0,0 +1,343 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+var markdownTemplates *template.Template
+
+func init() {
+	base := template.New("base").Funcs(sprig.FuncMap())
+
+	if !globHasMatches("generators/templates/markdown/*") {
+		markdownTemplates = base
+		return
+	}
+
+	var err error
+
+	markdownTemplates, err = base.ParseGlob("generators/templates/markdown/*")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MarkdownWriter is the Markdown counterpart of HTMLWriter: it walks the
+// same spec, in the same order, but renders each piece through the
+// templates under generators/templates/markdown/ and concatenates the
+// result into a single page, so the generated docs can be fed straight
+// into a static-site generator that already understands Markdown.
+type MarkdownWriter struct {
+	Config *api.Config
+	TOC    TOC
+
+	// currentTOCItem is used to remember the current item between
+	// calls to e.g. WriteResourceCategory() followed by WriteResource().
+	currentTOCItem *TOCItem
+}
+
+func NewMarkdownWriter(config *api.Config, title string) (DocWriter, error) {
+	writer := MarkdownWriter{
+		Config: config,
+		TOC: TOC{
+			Title:    title,
+			Sections: []*TOCItem{},
+		},
+	}
+
+	return &writer, nil
+}
+
+func (m *MarkdownWriter) render(filename string, data any) (string, error) {
+	var buf strings.Builder
+
+	if err := renderTo(markdownTemplates, &buf, filename, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func (m *MarkdownWriter) writeStaticFile(filename, templateName string, data any) error {
+	content, err := m.render(templateName, data)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(api.IncludesDir, filename), []byte(content), 0644)
+}
+
+func (m *MarkdownWriter) WriteOverview() error {
+	filename := "_overview.md"
+	if err := m.writeStaticFile(filename, "section-heading.md", "API Overview"); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level:      1,
+		PlainTitle: "Overview",
+		Link:       "api-overview",
+		File:       filename,
+	}
+	m.TOC.Sections = append(m.TOC.Sections, &item)
+	m.currentTOCItem = &item
+
+	return nil
+}
+
+func (m *MarkdownWriter) WriteAPIGroupVersions(gvs api.GroupVersions) error {
+	groups := api.ApiGroups{}
+	for group := range gvs {
+		groups = append(groups, api.ApiGroup(group))
+	}
+	sort.Sort(groups)
+
+	tplGroups := []map[string]any{}
+
+	for _, group := range groups {
+		versionList := gvs[group.String()]
+		sort.Sort(versionList)
+		var versions []string
+		for _, v := range versionList {
+			versions = append(versions, v.String())
+		}
+
+		tplGroups = append(tplGroups, map[string]any{
+			"group":    group,
+			"versions": versions,
+		})
+	}
+
+	fn := "_api_groups.md"
+	content, err := m.render("api-groups.md", map[string]any{
+		"groups": tplGroups,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(api.IncludesDir, fn), []byte(content), 0644); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level:      1,
+		PlainTitle: "API Groups",
+		Link:       "api-groups",
+		File:       fn,
+	}
+	m.TOC.Sections = append(m.TOC.Sections, &item)
+	m.currentTOCItem = &item
+
+	return nil
+}
+
+func (m *MarkdownWriter) WriteResourceCategory(name, file string) error {
+	if err := m.writeStaticFile("_"+file+".md", "resource-category-heading.md", name); err != nil {
+		return err
+	}
+
+	link := strings.ReplaceAll(strings.ToLower(name), " ", "-")
+	item := TOCItem{
+		Level:      1,
+		PlainTitle: name,
+		Link:       link,
+		File:       "_" + file + ".md",
+	}
+	m.TOC.Sections = append(m.TOC.Sections, &item)
+	m.currentTOCItem = &item
+
+	return nil
+}
+
+func (m *MarkdownWriter) WriteDefinitionsOverview() error {
+	if err := m.writeStaticFile("_definitions.md", "section-heading.md", "Definitions"); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level:      1,
+		PlainTitle: "Definitions",
+		Link:       "definitions",
+		File:       "_definitions.md",
+	}
+	m.TOC.Sections = append(m.TOC.Sections, &item)
+	m.currentTOCItem = &item
+
+	return nil
+}
+
+func (m *MarkdownWriter) WriteOrphanedOperationsOverview() error {
+	if err := m.writeStaticFile("_operations.md", "section-heading.md", "Operations"); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level:      1,
+		PlainTitle: "Operations",
+		Link:       "operations",
+		File:       "_operations.md",
+	}
+	m.TOC.Sections = append(m.TOC.Sections, &item)
+	m.currentTOCItem = &item
+
+	return nil
+}
+
+func (m *MarkdownWriter) WriteDefinition(d *api.Definition) error {
+	fn := definitionFileName(d, ".md")
+	nvg := fmt.Sprintf("%s %s %s", d.Name, d.Version, d.GroupDisplayName())
+	linkID := getLink(nvg)
+
+	content, err := m.render("definition.md", map[string]any{
+		"nvg":        nvg,
+		"linkID":     linkID,
+		"definition": d,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(api.IncludesDir, fn), []byte(content), 0644); err != nil {
+		return err
+	}
+
+	// Definitions are added to the TOC to enable the generator to later collect
+	// all the individual definition files, but definitions will not show up
+	// in the nav tree because it would take up too much screen estate.
+	item := TOCItem{
+		Level:      2,
+		PlainTitle: nvg,
+		Link:       linkID,
+		File:       fn,
+	}
+	m.currentTOCItem.SubSections = append(m.currentTOCItem.SubSections, &item)
+
+	return nil
+}
+
+func (m *MarkdownWriter) WriteOperation(o *api.Operation) error {
+	fn := operationFileName(o, ".md")
+	nvg := o.ID
+	linkID := getLink(nvg)
+
+	content, err := m.render("operation.md", map[string]any{
+		"linkID":    linkID,
+		"nvg":       nvg,
+		"operation": o,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(api.IncludesDir, fn), []byte(content), 0644); err != nil {
+		return err
+	}
+
+	sort.Slice(o.HttpResponses, func(i, j int) bool {
+		return strings.Compare(o.HttpResponses[i].Name, o.HttpResponses[j].Name) < 0
+	})
+
+	item := TOCItem{
+		Level:      2,
+		PlainTitle: nvg,
+		Link:       linkID,
+		File:       fn,
+	}
+	m.currentTOCItem.SubSections = append(m.currentTOCItem.SubSections, &item)
+
+	return nil
+}
+
+func (m *MarkdownWriter) WriteResource(r *api.Resource) error {
+	filename := conceptFileName(r.Definition, ".md")
+	dvg := fmt.Sprintf("%s %s %s", r.Name, r.Definition.Version, r.Definition.GroupDisplayName())
+	linkID := getLink(dvg)
+
+	resourceItem := TOCItem{
+		Level:      2,
+		PlainTitle: dvg,
+		Link:       linkID,
+		File:       filename,
+	}
+	m.currentTOCItem.SubSections = append(m.currentTOCItem.SubSections, &resourceItem)
+
+	resourceItem.SubSections = append(resourceItem.SubSections, operationCategoryTOCItems(r.Definition, nil)...)
+
+	content, err := m.render("resource.md", map[string]any{
+		"resource": r,
+		"dvg":      dvg,
+		"linkID":   linkID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(api.IncludesDir, filename), []byte(content), 0644)
+}
+
+func (m *MarkdownWriter) WriteOldVersionsOverview() error {
+	if err := m.writeStaticFile("_oldversions.md", "section-heading.md", "Old API Versions"); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level:      1,
+		PlainTitle: "Old API Versions",
+		Link:       "old-api-versions",
+		File:       "_oldversions.md",
+	}
+	m.TOC.Sections = append(m.TOC.Sections, &item)
+	m.currentTOCItem = &item
+
+	return nil
+}
+
+func (m *MarkdownWriter) Finalize() error {
+	if err := os.MkdirAll(api.BuildDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	return m.generateIndex()
+}
+
+func (m *MarkdownWriter) generateIndex() error {
+	// collect content from all the individual files we just created
+	content := collectTOCContent(m.TOC.Sections, false)
+
+	out, err := m.render("index.md", map[string]any{
+		"toc":     m.TOC,
+		"config":  m.Config,
+		"content": content,
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(api.BuildDir, "index.md"), []byte(out), 0644)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	_ "embed"
+	"encoding/json"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+//go:embed search.js
+var searchClientJS []byte
+
+// SearchRecord is one entry in search-index.json: everything the bundled
+// vanilla-JS client needs to match and rank a single TOC entry.
+//
+// Body is only populated from the TOC title for now; harvesting the full
+// description text of the underlying definition/operation/resource would
+// need collectSearchRecords to walk the api types directly instead of
+// just the TOC.
+type SearchRecord struct {
+	Title   string `json:"title"`
+	Link    string `json:"link"`
+	Kind    string `json:"kind"`
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Body    string `json:"body"`
+}
+
+// SearchIndexer turns a flat list of SearchRecords into the bytes written
+// to search-index.json. The default JSONSearchIndexer just writes them
+// as-is for the bundled client to score at query time; a consumer that
+// wants a lunr/minisearch-compatible pre-built index can plug in their
+// own SearchIndexer instead.
+type SearchIndexer interface {
+	BuildIndex(records []SearchRecord) ([]byte, error)
+}
+
+// JSONSearchIndexer is the default SearchIndexer.
+type JSONSearchIndexer struct{}
+
+func (JSONSearchIndexer) BuildIndex(records []SearchRecord) ([]byte, error) {
+	return json.MarshalIndent(records, "", "  ")
+}
+
+var tagStripper = regexp.MustCompile(`<[^>]*>`)
+
+// stripHTML removes markup (e.g. the "<span class=gvk>" badges gvkMarkup
+// produces) so a TOC title can be indexed as plain, searchable text.
+func stripHTML(s string) string {
+	return html.UnescapeString(tagStripper.ReplaceAllString(s, ""))
+}
+
+// kindForTOCLevel maps a TOCItem's nesting level to the "kind" the search
+// client uses to rank results: resources/definitions outrank operations,
+// which in turn outrank individual operation entries.
+func kindForTOCLevel(level int) string {
+	switch level {
+	case 1:
+		return "section"
+	case 2:
+		return "resource"
+	case 3:
+		return "operationCategory"
+	default:
+		return "operation"
+	}
+}
+
+// collectSearchRecords walks the TOC harvesting one record per item.
+func collectSearchRecords(toc TOC) []SearchRecord {
+	var records []SearchRecord
+
+	var walk func(items []*TOCItem)
+	walk = func(items []*TOCItem) {
+		for _, item := range items {
+			title := item.PlainTitle
+			if title == "" {
+				title = stripHTML(string(item.Title))
+			}
+
+			records = append(records, SearchRecord{
+				Title:   title,
+				Link:    item.Link,
+				Kind:    kindForTOCLevel(item.Level),
+				Group:   item.Group,
+				Version: item.Version,
+				Body:    title,
+			})
+
+			walk(item.SubSections)
+		}
+	}
+	walk(toc.Sections)
+
+	return records
+}
+
+// SearchIndexer lets a consumer swap the default JSON search-index.json
+// for a pluggable format (e.g. a pre-built lunr/minisearch index); it
+// defaults to JSONSearchIndexer when left nil.
+func (h *HTMLWriter) writeSearchIndex() error {
+	indexer := h.SearchIndexer
+	if indexer == nil {
+		indexer = JSONSearchIndexer{}
+	}
+
+	data, err := indexer.BuildIndex(collectSearchRecords(h.TOC))
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(api.BuildDir, "search-index.json"), data, 0644); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(api.BuildDir, "search.js"), searchClientJS, 0644)
+}
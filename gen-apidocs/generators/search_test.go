@@ -0,0 +1,88 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import "testing"
+
+func TestStripHTML(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{`<span class=gvk>apps/v1</span> Deployment`, "apps/v1 Deployment"},
+		{"Deployment", "Deployment"},
+	}
+
+	for _, c := range cases {
+		if got := stripHTML(c.in); got != c.want {
+			t.Errorf("stripHTML(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestKindForTOCLevel(t *testing.T) {
+	cases := []struct {
+		level int
+		want  string
+	}{
+		{1, "section"},
+		{2, "resource"},
+		{3, "operationCategory"},
+		{4, "operation"},
+	}
+
+	for _, c := range cases {
+		if got := kindForTOCLevel(c.level); got != c.want {
+			t.Errorf("kindForTOCLevel(%d) = %q, want %q", c.level, got, c.want)
+		}
+	}
+}
+
+func TestCollectSearchRecords(t *testing.T) {
+	toc := TOC{
+		Sections: []*TOCItem{
+			{
+				Level:      2,
+				PlainTitle: "Deployment apps/v1 apps",
+				Link:       "deployment-apps-v1-apps",
+				Group:      "apps",
+				Version:    "v1",
+				SubSections: []*TOCItem{
+					{
+						Level:      3,
+						PlainTitle: "Write",
+						Link:       "deployment-write",
+						Group:      "apps",
+						Version:    "v1",
+					},
+				},
+			},
+		},
+	}
+
+	records := collectSearchRecords(toc)
+	if len(records) != 2 {
+		t.Fatalf("len(records) = %d, want 2", len(records))
+	}
+
+	if records[0].Group != "apps" || records[0].Version != "v1" {
+		t.Errorf("records[0].Group/Version = %q/%q, want apps/v1", records[0].Group, records[0].Version)
+	}
+	if records[1].Group != "apps" || records[1].Version != "v1" {
+		t.Errorf("records[1].Group/Version = %q/%q, want apps/v1", records[1].Group, records[1].Version)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import "strings"
+
+// SpecFormat identifies which OpenAPI schema version a loaded spec
+// document uses.
+type SpecFormat string
+
+const (
+	SpecFormatSwagger2  SpecFormat = "swagger2"
+	SpecFormatOpenAPIv3 SpecFormat = "openapi3"
+)
+
+// DetectSpecFormat inspects the top-level keys of a decoded spec document
+// to tell a Swagger 2.0 document ("swagger": "2.0") apart from an OpenAPI
+// v3 document ("openapi": "3.0.x" / "3.1.x"), so LoadConfig (loader.go)
+// can pick the right parser before the result ever reaches a DocWriter.
+//
+// Kubernetes has published OpenAPI v3 per-GV documents since 1.23 at
+// /openapi/v3, and CRDs frequently ship only a v3 schema, so a spec
+// document can no longer be assumed to always be Swagger 2.0.
+func DetectSpecFormat(raw map[string]any) SpecFormat {
+	if openapi, ok := raw["openapi"].(string); ok && strings.HasPrefix(openapi, "3.") {
+		return SpecFormatOpenAPIv3
+	}
+
+	return SpecFormatSwagger2
+}
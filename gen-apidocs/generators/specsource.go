@@ -0,0 +1,346 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+
+	"gopkg.in/yaml.v2"
+)
+
+// SpecSource discovers the GroupVersions a set of per-GV spec documents
+// covers, and fetches each document's bytes on demand, so a caller
+// generating docs from a large GV index (a live cluster's /openapi/v3,
+// or a directory mirroring it) doesn't have to hold every document's
+// bytes in memory at once just to build the "API Groups" overview page.
+type SpecSource interface {
+	// Index lists the GroupVersions this source makes available, without
+	// fetching any of their spec documents.
+	Index() (api.GroupVersions, error)
+	// Fetch retrieves the raw spec document for a single GroupVersion.
+	Fetch(group api.ApiGroup, version api.ApiVersion) ([]byte, error)
+}
+
+// MapSource is a SpecSource backed by documents already loaded into
+// memory, e.g. by a caller assembling them itself instead of reading from
+// a file tree or a live cluster.
+type MapSource struct {
+	Docs map[api.ApiGroup]map[api.ApiVersion][]byte
+}
+
+func (s MapSource) Index() (api.GroupVersions, error) {
+	gvs := api.GroupVersions{}
+	for group, versions := range s.Docs {
+		var list api.VersionList
+		for version := range versions {
+			list = append(list, version)
+		}
+		sort.Sort(list)
+		gvs[group.String()] = list
+	}
+	return gvs, nil
+}
+
+func (s MapSource) Fetch(group api.ApiGroup, version api.ApiVersion) ([]byte, error) {
+	raw, ok := s.Docs[group][version]
+	if !ok {
+		return nil, fmt.Errorf("no document for group %q version %q", group, version)
+	}
+	return raw, nil
+}
+
+// FileSource reads per-GV spec documents from a directory, one file per
+// GroupVersion named "<group>@<version>.json" (the core group's files use
+// "core" in place of the empty group name, e.g. "core@v1.json" and
+// "apps@v1.json").
+type FileSource struct {
+	Dir string
+}
+
+func fileSourceName(group api.ApiGroup, version api.ApiVersion) string {
+	g := group.String()
+	if g == "" {
+		g = "core"
+	}
+	return fmt.Sprintf("%s@%s.json", g, version)
+}
+
+func (s FileSource) Index() (api.GroupVersions, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading spec directory %q: %w", s.Dir, err)
+	}
+
+	gvs := api.GroupVersions{}
+
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		if name == entry.Name() {
+			continue
+		}
+
+		group, version, ok := strings.Cut(name, "@")
+		if !ok {
+			continue
+		}
+		if group == "core" {
+			group = ""
+		}
+
+		gvs[group] = append(gvs[group], api.ApiVersion(version))
+	}
+
+	for group, list := range gvs {
+		sort.Sort(list)
+		gvs[group] = list
+	}
+
+	return gvs, nil
+}
+
+func (s FileSource) Fetch(group api.ApiGroup, version api.ApiVersion) ([]byte, error) {
+	path := filepath.Join(s.Dir, fileSourceName(group, version))
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading spec document %q: %w", path, err)
+	}
+
+	return raw, nil
+}
+
+// openAPIV3Index is the document a Kubernetes 1.23+ apiserver serves at
+// /openapi/v3: a map from a path (e.g. "api/v1" for the core group,
+// "apis/apps/v1" for a named group) to the URL that path's full spec
+// document lives at, relative to the apiserver's base URL.
+type openAPIV3Index struct {
+	Paths map[string]struct {
+		ServerRelativeURL string `json:"serverRelativeURL"`
+	} `json:"paths"`
+}
+
+// groupVersionFromOpenAPIV3Path splits an /openapi/v3 index path into its
+// GroupVersion, e.g. "api/v1" -> ("", "v1") and "apis/apps/v1" ->
+// ("apps", "v1").
+func groupVersionFromOpenAPIV3Path(path string) (api.ApiGroup, api.ApiVersion, bool) {
+	parts := strings.Split(path, "/")
+
+	switch {
+	case len(parts) == 2 && parts[0] == "api":
+		return "", api.ApiVersion(parts[1]), true
+	case len(parts) == 3 && parts[0] == "apis":
+		return api.ApiGroup(parts[1]), api.ApiVersion(parts[2]), true
+	default:
+		return "", "", false
+	}
+}
+
+// HTTPSource fetches per-GV spec documents from a live apiserver's
+// /openapi/v3 endpoint.
+type HTTPSource struct {
+	// BaseURL is the apiserver's base URL, e.g. "https://localhost:6443".
+	BaseURL string
+	// Client is the http.Client used for every request; left nil, it
+	// defaults to http.DefaultClient.
+	Client *http.Client
+
+	paths map[string]string // "group/version" -> serverRelativeURL
+}
+
+func (s *HTTPSource) client() *http.Client {
+	if s.Client != nil {
+		return s.Client
+	}
+	return http.DefaultClient
+}
+
+func (s *HTTPSource) get(path string) ([]byte, error) {
+	resp, err := s.client().Get(strings.TrimSuffix(s.BaseURL, "/") + path)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", path, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func (s *HTTPSource) Index() (api.GroupVersions, error) {
+	raw, err := s.get("/openapi/v3")
+	if err != nil {
+		return nil, err
+	}
+
+	var index openAPIV3Index
+	if err := json.Unmarshal(raw, &index); err != nil {
+		return nil, fmt.Errorf("decoding /openapi/v3 index: %w", err)
+	}
+
+	gvs := api.GroupVersions{}
+	s.paths = make(map[string]string, len(index.Paths))
+
+	for path, entry := range index.Paths {
+		group, version, ok := groupVersionFromOpenAPIV3Path(path)
+		if !ok {
+			continue
+		}
+
+		gvs[group.String()] = append(gvs[group.String()], version)
+		s.paths[group.String()+"/"+version.String()] = entry.ServerRelativeURL
+	}
+
+	for group, list := range gvs {
+		sort.Sort(list)
+		gvs[group] = list
+	}
+
+	return gvs, nil
+}
+
+func (s *HTTPSource) Fetch(group api.ApiGroup, version api.ApiVersion) ([]byte, error) {
+	if s.paths == nil {
+		if _, err := s.Index(); err != nil {
+			return nil, err
+		}
+	}
+
+	path, ok := s.paths[group.String()+"/"+version.String()]
+	if !ok {
+		return nil, fmt.Errorf("no /openapi/v3 entry for group %q version %q", group, version)
+	}
+
+	return s.get(path)
+}
+
+// kubeconfig is the minimal subset of a kubeconfig file KubeconfigSource
+// reads: the current context's server URL and the matching user's bearer
+// token. It does not support exec/OIDC/client-certificate auth plugins or
+// multi-file merging, the way client-go's discovery client does.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Contexts       []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Clusters []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server string `yaml:"server"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token string `yaml:"token"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// KubeconfigSource fetches per-GV spec documents from the cluster named
+// by a kubeconfig file's current context, authenticating with that user's
+// bearer token. It is a deliberately reduced substitute for client-go's
+// discovery client: no exec/OIDC/client-certificate auth plugins, and no
+// multi-file KUBECONFIG merging, since pulling in client-go for those is
+// out of proportion to what this package otherwise depends on.
+type KubeconfigSource struct {
+	Path string
+}
+
+func (s KubeconfigSource) httpSource() (*HTTPSource, error) {
+	raw, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("reading kubeconfig %q: %w", s.Path, err)
+	}
+
+	var cfg kubeconfig
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing kubeconfig %q: %w", s.Path, err)
+	}
+
+	var clusterName, userName string
+	for _, c := range cfg.Contexts {
+		if c.Name == cfg.CurrentContext {
+			clusterName = c.Context.Cluster
+			userName = c.Context.User
+		}
+	}
+
+	var server string
+	for _, c := range cfg.Clusters {
+		if c.Name == clusterName {
+			server = c.Cluster.Server
+		}
+	}
+	if server == "" {
+		return nil, fmt.Errorf("kubeconfig %q: no cluster for context %q", s.Path, cfg.CurrentContext)
+	}
+
+	var token string
+	for _, u := range cfg.Users {
+		if u.Name == userName {
+			token = u.User.Token
+		}
+	}
+
+	client := http.DefaultClient
+	if token != "" {
+		client = &http.Client{Transport: bearerTokenTransport{token: token}}
+	}
+
+	return &HTTPSource{BaseURL: server, Client: client}, nil
+}
+
+func (s KubeconfigSource) Index() (api.GroupVersions, error) {
+	source, err := s.httpSource()
+	if err != nil {
+		return nil, err
+	}
+	return source.Index()
+}
+
+func (s KubeconfigSource) Fetch(group api.ApiGroup, version api.ApiVersion) ([]byte, error) {
+	source, err := s.httpSource()
+	if err != nil {
+		return nil, err
+	}
+	return source.Fetch(group, version)
+}
+
+type bearerTokenTransport struct {
+	token string
+}
+
+func (t bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+func TestMapSource(t *testing.T) {
+	source := MapSource{Docs: map[api.ApiGroup]map[api.ApiVersion][]byte{
+		"":     {"v1": []byte("core-v1")},
+		"apps": {"v1": []byte("apps-v1")},
+	}}
+
+	gvs, err := source.Index()
+	if err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	want := api.GroupVersions{"": {"v1"}, "apps": {"v1"}}
+	if !reflect.DeepEqual(gvs, want) {
+		t.Errorf("Index() = %+v, want %+v", gvs, want)
+	}
+
+	raw, err := source.Fetch("apps", "v1")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if string(raw) != "apps-v1" {
+		t.Errorf("Fetch() = %q, want %q", raw, "apps-v1")
+	}
+
+	if _, err := source.Fetch("missing", "v1"); err == nil {
+		t.Error("Fetch() error = nil, want error for unknown GroupVersion")
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile(%q) error = %v", name, err)
+		}
+	}
+	writeFile("core@v1.json", "core-v1")
+	writeFile("apps@v1.json", "apps-v1")
+	writeFile("README.md", "not a spec document")
+
+	source := FileSource{Dir: dir}
+
+	gvs, err := source.Index()
+	if err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	want := api.GroupVersions{"": {"v1"}, "apps": {"v1"}}
+	if !reflect.DeepEqual(gvs, want) {
+		t.Errorf("Index() = %+v, want %+v", gvs, want)
+	}
+
+	raw, err := source.Fetch("apps", "v1")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if string(raw) != "apps-v1" {
+		t.Errorf("Fetch() = %q, want %q", raw, "apps-v1")
+	}
+
+	raw, err = source.Fetch("", "v1")
+	if err != nil {
+		t.Fatalf("Fetch(core) error = %v", err)
+	}
+	if string(raw) != "core-v1" {
+		t.Errorf("Fetch(core) = %q, want %q", raw, "core-v1")
+	}
+}
+
+func TestHTTPSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/openapi/v3":
+			fmt.Fprint(w, `{"paths": {
+				"api/v1": {"serverRelativeURL": "/openapi/v3/api/v1"},
+				"apis/apps/v1": {"serverRelativeURL": "/openapi/v3/apis/apps/v1"}
+			}}`)
+		case "/openapi/v3/api/v1":
+			fmt.Fprint(w, "core-v1")
+		case "/openapi/v3/apis/apps/v1":
+			fmt.Fprint(w, "apps-v1")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	source := &HTTPSource{BaseURL: server.URL}
+
+	gvs, err := source.Index()
+	if err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	want := api.GroupVersions{"": {"v1"}, "apps": {"v1"}}
+	if !reflect.DeepEqual(gvs, want) {
+		t.Errorf("Index() = %+v, want %+v", gvs, want)
+	}
+
+	raw, err := source.Fetch("apps", "v1")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if string(raw) != "apps-v1" {
+		t.Errorf("Fetch() = %q, want %q", raw, "apps-v1")
+	}
+}
+
+func TestHTTPSourceFetchWithoutIndex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/openapi/v3":
+			fmt.Fprint(w, `{"paths": {"api/v1": {"serverRelativeURL": "/openapi/v3/api/v1"}}}`)
+		case "/openapi/v3/api/v1":
+			fmt.Fprint(w, "core-v1")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	source := &HTTPSource{BaseURL: server.URL}
+
+	raw, err := source.Fetch("", "v1")
+	if err != nil {
+		t.Fatalf("Fetch() without prior Index() error = %v", err)
+	}
+	if string(raw) != "core-v1" {
+		t.Errorf("Fetch() = %q, want %q", raw, "core-v1")
+	}
+}
+
+func TestKubeconfigSource(t *testing.T) {
+	var gotAuth string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/openapi/v3":
+			fmt.Fprint(w, `{"paths": {"api/v1": {"serverRelativeURL": "/openapi/v3/api/v1"}}}`)
+		case "/openapi/v3/api/v1":
+			fmt.Fprint(w, "core-v1")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "kubeconfig.yaml")
+	contents := fmt.Sprintf(`
+current-context: test
+contexts:
+- name: test
+  context:
+    cluster: test-cluster
+    user: test-user
+clusters:
+- name: test-cluster
+  cluster:
+    server: %s
+users:
+- name: test-user
+  user:
+    token: s3cr3t
+`, server.URL)
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	source := KubeconfigSource{Path: path}
+
+	raw, err := source.Fetch("", "v1")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if string(raw) != "core-v1" {
+		t.Errorf("Fetch() = %q, want %q", raw, "core-v1")
+	}
+	if gotAuth != "Bearer s3cr3t" {
+		t.Errorf("Authorization header = %q, want %q", gotAuth, "Bearer s3cr3t")
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"html/template"
 	"io"
+	"path/filepath"
 
 	"github.com/Masterminds/sprig/v3"
 )
@@ -27,20 +28,46 @@ import (
 var templates *template.Template
 
 func init() {
+	base := template.New("base").Funcs(sprig.FuncMap()).Funcs(template.FuncMap{})
+
+	if !globHasMatches("generators/templates/*") {
+		templates = base
+		return
+	}
+
 	var err error
 
-	templates, err = template.
-		New("base").
-		Funcs(sprig.FuncMap()).
-		Funcs(template.FuncMap{}).
-		ParseGlob("generators/templates/*")
+	templates, err = base.ParseGlob("generators/templates/*")
 	if err != nil {
 		panic(err)
 	}
 }
 
+// globHasMatches reports whether pattern matches at least one file. The
+// three writer init()s use it to skip ParseGlob instead of hitting its
+// "pattern matches no files" error when a format's template directory
+// hasn't been checked into a given working tree.
+func globHasMatches(pattern string) bool {
+	matches, err := filepath.Glob(pattern)
+	return err == nil && len(matches) > 0
+}
+
+// templateExecutor is satisfied by both html/template.Template and
+// text/template.Template, letting renderTo serve every DocWriter
+// regardless of which template package its backend needs: HTMLWriter
+// relies on html/template's auto-escaping, while writers that target a
+// plain-text format (MarkdownWriter, AsciiDocWriter) parse their own
+// template set with text/template so escaping doesn't mangle the output.
+type templateExecutor interface {
+	ExecuteTemplate(wr io.Writer, name string, data any) error
+}
+
+func renderTo(t templateExecutor, dst io.Writer, filename string, data any) error {
+	return t.ExecuteTemplate(dst, filename, data)
+}
+
 func renderTemplateTo(dst io.Writer, filename string, data any) error {
-	return templates.ExecuteTemplate(dst, filename, data)
+	return renderTo(templates, dst, filename, data)
 }
 
 func renderTemplate(filename string, data any) (template.HTML, error) {
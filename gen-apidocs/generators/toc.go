@@ -0,0 +1,46 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import "html/template"
+
+// TOCItem and TOC are the format-agnostic building blocks every DocWriter
+// assembles while walking the spec. Title carries rich HTML markup (e.g.
+// the "<span class=gvk>" badges produced by gvkMarkup) for writers that can
+// render it as-is; PlainTitle carries the same label with markup stripped
+// down to plain text for writers whose target format has no inline HTML,
+// such as MarkdownWriter and AsciiDocWriter.
+type TOCItem struct {
+	Level       int
+	Title       template.HTML
+	PlainTitle  string
+	Link        string
+	File        string
+	SubSections []*TOCItem
+
+	// Group and Version identify the API group/version an item documents,
+	// e.g. "apps"/"v1" for a Deployment resource. Left empty for items
+	// (section headings, the operations/definitions overviews) that don't
+	// belong to a single GroupVersion.
+	Group   string
+	Version string
+}
+
+type TOC struct {
+	Title    string
+	Sections []*TOCItem
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+// This file holds the logic shared by every DocWriter implementation:
+// turning a label into a stable link ID, and naming the per-item include
+// files each writer drops into api.IncludesDir before Finalize collects
+// them. None of it is specific to HTML, so new backends (Markdown,
+// AsciiDoc, ...) only need to pick their own file extension.
+
+var linkIDDisallowed = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// getLink turns an arbitrary label (e.g. "Pod v1 core") into the slug used
+// as both an anchor and a cross-reference key, so that a resource,
+// definition or operation resolves to the same link no matter which
+// DocWriter produced the page it lives on.
+func getLink(s string) string {
+	slug := strings.ToLower(strings.TrimSpace(s))
+	slug = strings.ReplaceAll(slug, " ", "-")
+	slug = linkIDDisallowed.ReplaceAllString(slug, "")
+	return slug
+}
+
+// definitionFileName, operationFileName and conceptFileName name the
+// per-item include file a DocWriter writes into api.IncludesDir. ext is the
+// format-specific file extension, e.g. ".html", ".md" or ".adoc".
+func definitionFileName(d *api.Definition, ext string) string {
+	return fmt.Sprintf("_definition_%s%s", getLink(fmt.Sprintf("%s %s %s", d.Name, d.Version, d.GroupDisplayName())), ext)
+}
+
+func operationFileName(o *api.Operation, ext string) string {
+	return fmt.Sprintf("_operation_%s%s", getLink(o.ID), ext)
+}
+
+func conceptFileName(d *api.Definition, ext string) string {
+	return fmt.Sprintf("_concept_%s%s", getLink(fmt.Sprintf("%s %s %s", d.Name, d.Version, d.GroupDisplayName())), ext)
+}
+
+// writeStaticFile writes already-rendered content for a section heading or
+// other one-off page straight into api.IncludesDir.
+func writeStaticFile(filename string, content template.HTML) error {
+	return os.WriteFile(filepath.Join(api.IncludesDir, filename), []byte(content), 0644)
+}
+
+// operationCategoryTOCItems builds the level-3/level-4 TOC entries listing a
+// resource's non-empty operation categories and their operations, shared by
+// every backend's WriteResource. title, when non-nil, renders each entry's
+// Title markup and stamps its Group/Version, the way HTMLWriter's nav tree
+// and search index need; left nil, only PlainTitle/Link are set, matching
+// Markdown/AsciiDoc's plainer TOC entries.
+func operationCategoryTOCItems(def *api.Definition, title func(name string) template.HTML) []*TOCItem {
+	var items []*TOCItem
+
+	for _, oc := range def.OperationCategories {
+		if len(oc.Operations) == 0 {
+			continue
+		}
+
+		ocItem := &TOCItem{
+			Level:      3,
+			PlainTitle: oc.Name,
+			Link:       oc.TocID(def),
+		}
+		if title != nil {
+			ocItem.Title = title(oc.Name)
+			ocItem.Group = def.GroupDisplayName()
+			ocItem.Version = def.Version.String()
+		}
+
+		for _, o := range oc.Operations {
+			opItem := &TOCItem{
+				Level:      4,
+				PlainTitle: o.Type.Name,
+				Link:       o.TocID(def),
+			}
+			if title != nil {
+				opItem.Title = title(o.Type.Name)
+				opItem.Group = def.GroupDisplayName()
+				opItem.Version = def.Version.String()
+			}
+			ocItem.SubSections = append(ocItem.SubSections, opItem)
+		}
+
+		items = append(items, ocItem)
+	}
+
+	return items
+}
+
+// collectTOCContent concatenates the bytes of every per-item include file a
+// DocWriter wrote into api.IncludesDir, walking sections two SubSections
+// deep in the same order the nav tree lists them, so each backend's
+// generateIndex only needs to supply its own TOC and decide whether to log
+// progress the way HTMLWriter does.
+func collectTOCContent(sections []*TOCItem, verbose bool) string {
+	var content strings.Builder
+
+	collect := func(filename string) {
+		fileContent, err := os.ReadFile(filepath.Join(api.IncludesDir, filename))
+		if err != nil {
+			if verbose {
+				log.Printf("Collecting %s… \033[31mNot found\033[0m", filename)
+			}
+			return
+		}
+
+		content.Write(fileContent)
+		if verbose {
+			log.Printf("Collecting %s… \033[32mOK\033[0m", filename)
+		}
+	}
+
+	for _, sec := range sections {
+		collect(sec.File)
+
+		for _, sub := range sec.SubSections {
+			if len(sub.File) > 0 {
+				collect(sub.File)
+			}
+
+			for _, subsub := range sub.SubSections {
+				if len(subsub.File) > 0 {
+					collect(subsub.File)
+				}
+			}
+		}
+	}
+
+	return content.String()
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"html/template"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+func TestCollectTOCContent(t *testing.T) {
+	if err := os.MkdirAll(api.IncludesDir, 0755); err != nil {
+		t.Fatalf("MkdirAll(%q) error = %v", api.IncludesDir, err)
+	}
+	defer os.RemoveAll(api.IncludesDir)
+
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(api.IncludesDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile(%q) error = %v", name, err)
+		}
+	}
+	write("a.html", "A")
+	write("b.html", "B")
+	write("c.html", "C")
+
+	sections := []*TOCItem{
+		{
+			File: "a.html",
+			SubSections: []*TOCItem{
+				{
+					File: "b.html",
+					SubSections: []*TOCItem{
+						{File: "c.html"},
+						{}, // no File: must be skipped, not error out
+					},
+				},
+			},
+		},
+	}
+
+	if got := collectTOCContent(sections, false); got != "ABC" {
+		t.Errorf("collectTOCContent() = %q, want %q", got, "ABC")
+	}
+}
+
+func TestOperationCategoryTOCItems(t *testing.T) {
+	def := &api.Definition{
+		Name:    "Pod",
+		Version: "v1",
+		OperationCategories: []api.OperationCategory{
+			{Name: "Create", Operations: []*api.Operation{{ID: "createCoreV1NamespacedPod", Type: api.OperationType{Name: "Create"}}}},
+			{Name: "Empty"},
+		},
+	}
+
+	items := operationCategoryTOCItems(def, nil)
+	if len(items) != 1 {
+		t.Fatalf("len(items) = %d, want 1 (empty category must be skipped)", len(items))
+	}
+	if items[0].Title != "" {
+		t.Errorf("items[0].Title = %q, want empty when title func is nil", items[0].Title)
+	}
+	if len(items[0].SubSections) != 1 || items[0].SubSections[0].PlainTitle != "Create" {
+		t.Errorf("items[0].SubSections = %+v", items[0].SubSections)
+	}
+
+	rich := operationCategoryTOCItems(def, func(name string) template.HTML { return template.HTML("<b>" + name + "</b>") })
+	if rich[0].Title != "<b>Create</b>" {
+		t.Errorf("rich[0].Title = %q", rich[0].Title)
+	}
+	if rich[0].Group != def.GroupDisplayName() || rich[0].Version != def.Version.String() {
+		t.Errorf("rich[0].Group/Version = %q/%q", rich[0].Group, rich[0].Version)
+	}
+}